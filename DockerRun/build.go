@@ -0,0 +1,255 @@
+package DockerRun
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+//BuildFlags is all flags allowled to used in docker build and docker buildx build
+var BuildFlags = FlagSet{
+	Short: []string{"t", "f"},
+	Long:  []string{"tag", "file", "build-arg", "target", "no-cache", "platform", "label"},
+	NoArg: []string{"no-cache"},
+}
+
+//the model to simulate the property of a docker build command
+type MockBuild struct {
+	Context   string
+	Tags      []string
+	File      string
+	BuildArgs map[string]string
+	Target    string
+	Platform  string
+	Label     []string
+	NoCache   bool
+	IsBuildx  bool
+	Client    ClientConfig //the global options before the subcommand
+}
+
+//create an MockBuild according to a docker build command, return error if it command have a worng syntax
+func NewMockBuild(dockerCmd string) (model MockBuild, err error) {
+	model.BuildArgs = make(map[string]string)
+	cmdArray := splitCommand(dockerCmd)
+	if reason := model.basicCheck(cmdArray); !reason.IsEmpty() {
+		return model, reason
+	}
+	return model, nil
+}
+
+//check the basic syntax of a docker build command,
+//return the fall reason or return a empty string if the command is accpeted
+//synatax: docker build [OPTIONS] PATH | URL | -
+//         docker buildx build [OPTIONS] PATH | URL | -
+func (this *MockBuild) BasicCheck(cmd []string) string {
+	return this.basicCheck(cmd).String()
+}
+
+//check the basic syntax of a docker build command, return the fall reason or a empty message if the command is accpeted
+func (this *MockBuild) basicCheck(cmd []string) Message {
+	if len(cmd) == 0 {
+		return msg("run.empty")
+	}
+	if len(cmd) < 2 {
+		return msg("run.too_short")
+	}
+	if cmd[0] != "docker" {
+		return msg("run.not_docker")
+	}
+	cmd, reason := stripGlobalOptions(cmd, &this.Client)
+	if !reason.IsEmpty() {
+		return reason
+	}
+	cmd = normalizeCommand(cmd)
+	nowAt := 2
+	if cmd[1] == "buildx" && len(cmd) > 2 && cmd[2] == "build" {
+		this.IsBuildx = true
+		nowAt = 3
+	} else if cmd[1] != "build" {
+		return msg("command.not_kind", "build")
+	}
+	//the options can be placed both before and after the context, such as 'docker build . -t app'
+	for {
+		var reason Message
+		nowAt, reason = parseOptions(cmd, nowAt, BuildFlags, this)
+		if !reason.IsEmpty() {
+			return reason
+		}
+		if nowAt >= len(cmd) {
+			break
+		}
+		if this.Context != "" {
+			return msg("command.extra_arg", "build", cmd[nowAt])
+		}
+		this.Context = trimStr(cmd[nowAt])
+		nowAt++
+	}
+	if this.Context == "" {
+		return msg("command.exactly_one", "build")
+	}
+	return Message{}
+}
+
+//Setting up the property of a build according to the flag and argument
+//if the format of arguments not right it will return error
+func (this *MockBuild) HandleArgument(flag, arg string) error {
+	arg = trimStr(arg)
+	switch flag {
+	case "t", "tag":
+		if !isImagesName(arg) {
+			return msg("arg.tag", arg)
+		}
+		tag := withDefaultTag(arg)
+		if !findInArray(this.Tags, tag) {
+			this.Tags = append(this.Tags, tag)
+		}
+	case "f", "file":
+		if arg == "" {
+			return msg("arg.dockerfile", arg)
+		}
+		this.File = arg
+	case "build-arg":
+		kv := strings.SplitN(arg, "=", 2)
+		if kv[0] == "" {
+			return msg("arg.build_arg", arg)
+		}
+		if len(kv) == 1 {
+			kv = append(kv, "")
+		}
+		this.BuildArgs[kv[0]] = kv[1]
+	case "target":
+		this.Target = arg
+	case "platform":
+		if !isPlatform(arg) {
+			return msg("arg.platform", arg)
+		}
+		this.Platform = arg
+	case "label":
+		this.Label = append(this.Label, arg)
+	default:
+		return msg("flag.invalid", flag)
+	}
+	return nil
+}
+
+//Setting up the property of a build according to the flag that without argument
+//return error only if the flag is not exist
+func (this *MockBuild) HandleFlag(flag string) error {
+	switch flag {
+	case "no-cache":
+		this.NoCache = true
+	default:
+		return msg("flag.invalid_name", flag)
+	}
+	return nil
+}
+
+//judge if the property of a build is right by compared to the answer
+//return a string to describe the mistake or a null string if it command is accepted
+//note that here we have some config do not check: Label[] and whether buildx is used
+func JudgeBuild(test, ans *MockBuild) string {
+	return judgeBuild(test, ans).String()
+}
+
+//judge the property of a docker build command, return the mistake or a empty message if it command is accepted
+func judgeBuild(test, ans *MockBuild) Message {
+	if test == nil {
+		return msg("judge.null_test")
+	}
+	if ans == nil {
+		return msg("judge.null_ans")
+	}
+	if normalizeContext(test.Context) != normalizeContext(ans.Context) {
+		return msg("judge.build_context", ans.Context, test.Context)
+	}
+	for _, tag := range ans.Tags {
+		if !findInArray(test.Tags, tag) {
+			return msg("judge.tag", tag)
+		}
+	}
+	for _, tag := range test.Tags {
+		if !findInArray(ans.Tags, tag) {
+			return msg("judge.unexpect_tag", tag)
+		}
+	}
+	if normalizeDockerfile(test.File) != normalizeDockerfile(ans.File) {
+		return msg("judge.dockerfile", normalizeDockerfile(ans.File), normalizeDockerfile(test.File))
+	}
+	for k, v := range ans.BuildArgs {
+		tv, have := test.BuildArgs[k]
+		if !have || tv != v {
+			return msg("judge.build_arg", k, v, tv)
+		}
+	}
+	for k := range test.BuildArgs {
+		if _, have := ans.BuildArgs[k]; !have {
+			return msg("judge.unexpect_build_arg", k)
+		}
+	}
+	if ans.Target != test.Target {
+		return msg("judge.target", ans.Target, test.Target)
+	}
+	if ans.Platform != "" && test.Platform != ans.Platform {
+		return msg("judge.platform", ans.Platform, test.Platform)
+	}
+	if ans.NoCache && !test.NoCache {
+		return msg("judge.flag", "--no-cache")
+	}
+	if reason := judgeClient(&test.Client, &ans.Client); !reason.IsEmpty() {
+		return reason
+	}
+	return Message{}
+}
+
+//build the images like 'docker build', the tags are added to the images of engine
+func (this *MockEngine) Build(build *MockBuild) error {
+	if len(build.Tags) == 0 { //a dangling image
+		this.created++
+		this.Images[fmt.Sprintf("<none>:%d", this.created)] = true
+	}
+	for _, tag := range build.Tags {
+		ref, err := NormalizeImageRef(tag)
+		if err != nil {
+			return err
+		}
+		this.Images[ref] = true
+	}
+	return nil
+}
+
+//change a build context into a clean form, such as ./ and $PWD are the same as .
+func normalizeContext(context string) string {
+	if strings.Contains(context, "://") || context == "-" {
+		return context
+	}
+	context = strings.Replace(context, "$(pwd)", "$PWD", 1)
+	context = strings.Replace(context, "${PWD}", "$PWD", 1)
+	if context == "$PWD" || strings.HasPrefix(context, "$PWD/") {
+		context = "." + strings.TrimPrefix(context, "$PWD")
+	}
+	return path.Clean(context)
+}
+
+//change the path of dockerfile into a clean form, the default one is Dockerfile
+func normalizeDockerfile(file string) string {
+	if file == "" {
+		return "Dockerfile"
+	}
+	return path.Clean(file)
+}
+
+//check if the argument can be used by flag --platform, such as linux/amd64 or linux/arm64/v8
+func isPlatform(arg string) bool {
+	for _, p := range strings.Split(arg, ",") {
+		parts := strings.Split(p, "/")
+		if len(parts) < 2 || len(parts) > 3 {
+			return false
+		}
+		for _, part := range parts {
+			if !isContainerName(part) {
+				return false
+			}
+		}
+	}
+	return true
+}
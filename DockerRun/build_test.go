@@ -0,0 +1,48 @@
+package DockerRun
+
+import (
+	"testing"
+)
+
+func TestMockBuild(t *testing.T) {
+	standard := `docker build -t username/app:v1 -f Dockerfile --build-arg MODE=prod .`
+	challenger := []string{
+		`docker build --tag=username/app:v1 --build-arg=MODE=prod ./`,
+		`sudo docker build . -t username/app:v1 --build-arg MODE=prod`,
+		`docker buildx build -t "username/app:v1" --file ./Dockerfile --build-arg MODE=prod $PWD`,
+	}
+	failed := []string{
+		`docker build -t username/app:v1 --build-arg MODE=prod`,         //no context
+		`docker build -t username/app:v1 --build-arg MODE=prod . extra`, //too many context
+		`docker build -t username/app: --build-arg MODE=prod .`,         //invalid tag
+		`docker build --platform linux -t username/app:v1 .`,            //invalid platform
+		`docker build --rm-cache -t username/app:v1 .`,                  //unknown flag
+	}
+	stdBuild, err := NewMockBuild(standard)
+	if err != nil {
+		t.Fatalf("Standard command fall: %v", err)
+	}
+	for i, cmd := range challenger {
+		build, err := NewMockBuild(cmd)
+		if err != nil {
+			t.Fatalf("Create build fail at command %d : %v", i, err)
+		}
+		if res := JudgeBuild(&build, &stdBuild); res != "" {
+			t.Fatalf("Unpass at command %d : %v", i, res)
+		}
+	}
+	for _, cmd := range failed {
+		if _, err := NewMockBuild(cmd); err == nil {
+			t.Fatalf("worng command %s pass!", cmd)
+		}
+	}
+	build, _ := NewMockBuild(`docker build -t username/app --build-arg MODE=prod .`)
+	if JudgeBuild(&build, &stdBuild) == "" {
+		t.Fatalf("build with worng tag should not pass")
+	}
+	engine := NewMockEngine()
+	engine.Build(&stdBuild)
+	if !engine.HasImage("username/app:v1") {
+		t.Fatalf("built images should be added to the engine")
+	}
+}
@@ -0,0 +1,79 @@
+package DockerRun
+
+import "strings"
+
+//the command line tools that can be used to run a container
+const (
+	CLIDocker  = "docker"
+	CLIPodman  = "podman"
+	CLINerdctl = "nerdctl"
+)
+
+//SupportedCLIs is the command line tools that compatible with docker run
+var SupportedCLIs = []string{CLIDocker, CLIPodman, CLINerdctl}
+
+var (
+	//the flags of run that only exist in the other command line tools
+	cliExtraFlags = map[string][]string{
+		CLIPodman: {"pod"},
+	}
+	//the flags of docker run that not supported by the other command line tools
+	cliAbsentFlags = map[string][]string{
+		CLIPodman:  {"link"},
+		CLINerdctl: {"link"},
+	}
+	//the arguments of --userns that can be used by each command line tool
+	cliUserNSModes = map[string][]string{
+		CLIDocker:  {"host"},
+		CLIPodman:  {"host", "keep-id", "auto", "nomap", "private"},
+		CLINerdctl: {"host"},
+	}
+	//the options of volume that can be used by each command line tool, such as the U in -v data:/data:U
+	cliVolumeOptions = map[string][]string{
+		CLIDocker: {"ro", "rw", "z", "Z", "consistent", "cached", "delegated", "nocopy",
+			"shared", "slave", "private", "rshared", "rslave", "rprivate"},
+		CLIPodman: {"ro", "rw", "z", "Z", "U", "O", "exec", "noexec", "suid", "nosuid", "dev", "nodev",
+			"shared", "slave", "private", "rshared", "rslave", "rprivate", "copy", "nocopy"},
+		CLINerdctl: {"ro", "rw", "rro", "shared", "slave", "private", "rshared", "rslave", "rprivate"},
+	}
+)
+
+//return the command line tool of the container, the default one is docker
+func (this *MockContainer) cli() string {
+	if this.CLI == "" {
+		return CLIDocker
+	}
+	return this.CLI
+}
+
+//return the flags of run that can be used by the command line tool
+func runFlagsOf(cli string) FlagSet {
+	flagSet := FlagSet{Short: RunFlags.Short, NoArg: RunFlags.NoArg}
+	for _, flag := range RunFlags.Long {
+		if !findInArray(cliAbsentFlags[cli], flag) {
+			flagSet.Long = append(flagSet.Long, flag)
+		}
+	}
+	flagSet.Long = append(flagSet.Long, cliExtraFlags[cli]...)
+	return flagSet
+}
+
+//check if the argument of --userns can be used by the command line tool, such as keep-id of podman
+//the arguments with a value such as keep-id:uid=1000 and ns:/proc/1/ns/user are also accepted by podman
+func isUserNSMode(cli, arg string) bool {
+	mode := strings.SplitN(arg, ":", 2)[0]
+	if cli == CLIPodman && (mode == "ns" || mode == "container") {
+		return strings.Contains(arg, ":")
+	}
+	return findInArray(cliUserNSModes[cli], mode)
+}
+
+//check the options of a volume such as ro,Z and return the error if some of them can't be used by the command line tool
+func checkVolumeOptions(cli, options string) error {
+	for _, opt := range strings.Split(options, ",") {
+		if !findInArray(cliVolumeOptions[cli], opt) {
+			return msg("arg.volume_mode", cli, opt)
+		}
+	}
+	return nil
+}
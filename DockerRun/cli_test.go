@@ -0,0 +1,59 @@
+package DockerRun
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompatibleCLI(t *testing.T) {
+	standard := `podman run -d --pod web --userns=keep-id -v $PWD/data:/data:U,Z --name db mysql`
+	challenger := []string{
+		`podman run -d --pod=web --userns keep-id -v $PWD/data:/data:Z,U --name db mysql`,
+		`sudo podman container run -d --pod web --userns=keep-id -v ${PWD}/data:/data:U,Z --name db mysql`,
+	}
+	stdCtr, err := NewMockContainer(standard)
+	if err != nil {
+		t.Fatalf("Standard command fall: %v", err)
+	}
+	for i, cmd := range challenger {
+		ctr, err := NewMockContainer(cmd)
+		if err != nil {
+			t.Fatalf("Create container fail at command %d : %v", i, err)
+		}
+		if res := Judge(&ctr, &stdCtr); res != "" {
+			t.Fatalf("Unpass at command %d : %v", i, res)
+		}
+	}
+	for _, cmd := range []string{
+		`docker run --pod web mysql`,
+		`docker run --userns=keep-id mysql`,
+		`docker run -v $PWD/data:/data:U mysql`,
+		`nerdctl run --link db:db mysql`,
+		`nerdctl run --pod web mysql`,
+		`runc run mysql`,
+	} {
+		if _, err := NewMockContainer(cmd); err == nil {
+			t.Fatalf("worng command %s pass!", cmd)
+		}
+	}
+	ctr, _ := NewMockContainer(`podman run -d --userns=keep-id -v $PWD/data:/data:Z --name db mysql`)
+	if res := Judge(&ctr, &stdCtr); !strings.Contains(res, "Volume option") {
+		t.Fatalf("volume without U should not pass: %s", res)
+	}
+	ans, _ := NewMockContainer(`docker run -v data:/data mysql`)
+	ctr, _ = NewMockContainer(`nerdctl run -v data:/data:ro mysql`)
+	if Judge(&ctr, &ans) == "" {
+		t.Fatalf("read only volume should not pass")
+	}
+	exercise := Exercise{Answer: `docker run -d --name db mysql`}
+	if exercise.Judge(`podman run -d --name db mysql`) == "" {
+		t.Fatalf("podman should not be allowed by default")
+	}
+	exercise.AllowedCLIs = []string{CLIDocker, CLIPodman}
+	if res := exercise.Judge(`podman run -d --name db mysql`); res != "" {
+		t.Fatalf("podman should be allowed: %s", res)
+	}
+	if exercise.Judge(`nerdctl run -d --name db mysql`) == "" {
+		t.Fatalf("nerdctl should not be allowed")
+	}
+}
@@ -0,0 +1,140 @@
+package DockerRun
+
+import "regexp"
+
+//GlobalFlags is all global options of docker client that allowled to used before the subcommand
+var GlobalFlags = FlagSet{
+	Short: []string{"H", "D", "l", "c"},
+	Long:  []string{"host", "context", "log-level", "debug", "config", "tls", "tlsverify", "tlscacert", "tlscert", "tlskey"},
+	NoArg: []string{"D", "debug", "tls", "tlsverify"},
+}
+
+//the model to simulate the global options of docker client, such as docker -H tcp://host:2375 run ...
+type ClientConfig struct {
+	Hosts       []string
+	Context     string
+	LogLevel    string
+	Config      string
+	TLSCACert   string
+	TLSCert     string
+	TLSKey      string
+	IsDebug     bool
+	IsTLS       bool
+	IsTLSVerify bool
+}
+
+//parse the global options of docker client into client and strip them from the command,
+//return the command start with docker and the subcommand, and the fall reason or a empty message if the options are accpeted
+func stripGlobalOptions(cmd []string, client *ClientConfig) ([]string, Message) {
+	if len(cmd) < 2 || cmd[0] != "docker" {
+		return cmd, Message{}
+	}
+	nowAt, reason := parseOptions(cmd, 1, GlobalFlags, client)
+	if !reason.IsEmpty() {
+		return cmd, reason
+	}
+	if nowAt >= len(cmd) {
+		return cmd, msg("client.no_subcommand")
+	}
+	if len(client.Hosts) > 0 && client.Context != "" {
+		return cmd, msg("client.host_context")
+	}
+	if nowAt == 1 {
+		return cmd, Message{}
+	}
+	return append([]string{"docker"}, cmd[nowAt:]...), Message{}
+}
+
+//Setting up the property of client according to the flag and argument
+//if the format of arguments not right it will return error
+func (this *ClientConfig) HandleArgument(flag, arg string) error {
+	arg = trimStr(arg)
+	switch flag {
+	case "H", "host":
+		host, ok := normalizeDaemonHost(arg)
+		if !ok {
+			return msg("client.host", arg)
+		}
+		this.Hosts = append(this.Hosts, host)
+	case "c", "context":
+		if !isContainerName(arg) {
+			return msg("client.context", arg)
+		}
+		this.Context = arg
+	case "l", "log-level":
+		if !findInArray([]string{"debug", "info", "warn", "error", "fatal"}, arg) {
+			return msg("client.log_level", arg)
+		}
+		this.LogLevel = arg
+	case "config":
+		this.Config = arg
+	case "tlscacert":
+		this.TLSCACert = arg
+	case "tlscert":
+		this.TLSCert = arg
+	case "tlskey":
+		this.TLSKey = arg
+	default:
+		return msg("flag.invalid", flag)
+	}
+	return nil
+}
+
+//Setting up the property of client according to the flag that without argument
+//return error only if the flag is not exist
+func (this *ClientConfig) HandleFlag(flag string) error {
+	switch flag {
+	case "D", "debug":
+		this.IsDebug = true
+	case "tls":
+		this.IsTLS = true
+	case "tlsverify":
+		this.IsTLSVerify = true
+	default:
+		return msg("flag.invalid_short", flag)
+	}
+	return nil
+}
+
+//judge if the global options is right by compared to the answer,
+//only the options that appear in the answer are required, the extra options of test are accepted
+//return a string to describe the mistake or a null string if it is accepted
+func JudgeClient(test, ans *ClientConfig) string {
+	return judgeClient(test, ans).String()
+}
+
+//judge the global options, return the mistake or a empty message if it is accepted
+func judgeClient(test, ans *ClientConfig) Message {
+	if test == nil || ans == nil {
+		return msg("judge.client_null")
+	}
+	for _, h := range ans.Hosts {
+		if !findInArray(test.Hosts, h) {
+			return msg("judge.host", h)
+		}
+	}
+	if ans.Context != "" && test.Context != ans.Context {
+		return msg("judge.context", ans.Context, test.Context)
+	}
+	if ans.LogLevel != "" && test.LogLevel != ans.LogLevel {
+		return msg("judge.log_level", ans.LogLevel, test.LogLevel)
+	}
+	if ans.IsDebug && !test.IsDebug {
+		return msg("judge.debug")
+	}
+	if ans.IsTLSVerify && !test.IsTLSVerify {
+		return msg("judge.tlsverify")
+	}
+	return Message{}
+}
+
+//check if the argument can be used by flag -H, such as tcp://host:2375, unix:///var/run/docker.sock or ssh://user@host,
+//a host without scheme such as host:2375 or 1.2.3.4:2375 is tcp by default, return the host with scheme
+func normalizeDaemonHost(arg string) (string, bool) {
+	if match, _ := regexp.MatchString(`^[\w.-]*(:\d+)?$`, arg); match && arg != "" && arg != ":" {
+		arg = "tcp://" + arg
+	}
+	pattern := `^(tcp://(\[[0-9a-fA-F:]+\]|[\w.-]*)(:\d+)?/?|unix:///[^ ]+|ssh://[^/ ]+|npipe:////[^ ]+|fd://[^ ]*)$`
+	match, _ := regexp.MatchString(pattern, arg)
+	return arg, match
+}
@@ -0,0 +1,61 @@
+package DockerRun
+
+import "testing"
+
+func TestGlobalOptions(t *testing.T) {
+	standard := `docker --context remote run -d --name server1 nginx`
+	challenger := []string{
+		`docker --context=remote run -d --name server1 nginx`,
+		`docker -c remote --log-level debug run -d --name server1 nginx`,
+		`sudo docker -D --context remote container run -d --name server1 nginx`,
+	}
+	stdCtr, err := NewMockContainer(standard)
+	if err != nil {
+		t.Fatalf("Standard command fall: %v", err)
+	}
+	for i, cmd := range challenger {
+		ctr, err := NewMockContainer(cmd)
+		if err != nil {
+			t.Fatalf("Create container fail at command %d : %v", i, err)
+		}
+		if res := Judge(&ctr, &stdCtr); res != "" {
+			t.Fatalf("Unpass at command %d : %v", i, res)
+		}
+	}
+	for _, cmd := range []string{
+		`docker run -d --name server1 nginx`,
+		`docker --context prod run -d --name server1 nginx`,
+	} {
+		ctr, _ := NewMockContainer(cmd)
+		if Judge(&ctr, &stdCtr) == "" {
+			t.Fatalf("command without context remote pass: %s", cmd)
+		}
+	}
+	for _, cmd := range []string{
+		`docker -H tcp://host:2375 --context remote run nginx`,
+		`docker -H tcp://host:x run nginx`,
+		`docker -H http://host:2375 run nginx`,
+		`docker --log-level verbose run nginx`,
+		`docker -D`,
+		`docker --rm run nginx`,
+	} {
+		if _, err := NewMockContainer(cmd); err == nil {
+			t.Fatalf("worng command %s pass!", cmd)
+		}
+	}
+	ctr, err := NewMockContainer(`docker -H tcp://host:2375 -H unix:///var/run/docker.sock run nginx`)
+	if err != nil || len(ctr.Client.Hosts) != 2 || ctr.Images != "nginx:latest" {
+		t.Fatalf("unexpect result of -H: %v %v", err, ctr.Client)
+	}
+	for arg, expect := range map[string]string{"host:2375": "tcp://host:2375", "1.2.3.4:2375": "tcp://1.2.3.4:2375", ":2375": "tcp://:2375"} {
+		if ctr, err := NewMockContainer(`docker -H ` + arg + ` run nginx`); err != nil || ctr.Client.Hosts[0] != expect {
+			t.Fatalf("unexpect result of -H %s: %v %v", arg, err, ctr.Client)
+		}
+	}
+	if e, err := NewMockExec(`docker -D exec -it server1 sh`); err != nil || !e.Client.IsDebug {
+		t.Fatalf("global options of exec fail: %v", err)
+	}
+	if n, err := NewMockNetwork(`docker --context remote network create netname`); err != nil || n.Client.Context != "remote" {
+		t.Fatalf("global options of network fail: %v", err)
+	}
+}
@@ -0,0 +1,216 @@
+package DockerRun
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+//the strategies to compare the command of a container, they can be combined except CompareExact
+const (
+	CompareExact     = "exact"     //compare the command and arguments token by token, it is the default one
+	CompareShell     = "shell"     //re-parse the quotes of the command and the script of sh -c, so 'pwd' and "pwd" are the same
+	CompareBasename  = "basename"  //compare the program by its name, so /bin/sh and sh are the same
+	CompareUnordered = "unordered" //the options of the command can be in any order, such as ls -a -l and ls -l -a
+	CompareRegex     = "regex"     //the command line should match the Pattern
+)
+
+//the shells that run the script after -c
+var shellPrograms = []string{"sh", "bash", "ash", "dash", "zsh"}
+
+//CommandMatch is the way that an exercise compare the command and arguments of a container
+type CommandMatch struct {
+	Strategies []string //the strategies in the order they are applied, CompareExact is used if it is empty
+	Pattern    string   //the regular expression that the whole command line should match when CompareRegex is used
+}
+
+//check if the strategies is the same as the original Judge
+func (this *CommandMatch) isExact() bool {
+	for _, s := range this.Strategies {
+		if s != CompareExact {
+			return false
+		}
+	}
+	return true
+}
+
+//check if the strategies are known and the Pattern is a regular expression when CompareRegex is used
+func (this *CommandMatch) validate() Message {
+	for _, s := range this.Strategies {
+		if !findInArray([]string{CompareExact, CompareShell, CompareBasename, CompareUnordered, CompareRegex}, s) {
+			return msg("judge.strategy", s)
+		}
+	}
+	if findInArray(this.Strategies, CompareRegex) {
+		if _, err := regexp.Compile("^(?:" + this.Pattern + ")$"); err != nil {
+			return msg("judge.pattern", err)
+		}
+	}
+	return Message{}
+}
+
+//compare the command and arguments of test with those of ans by the strategies,
+//return a string to describe the mistake or a null string if they are equivalent
+func (this *CommandMatch) Judge(test, ans *MockContainer) string {
+	return this.judge(test, ans).String()
+}
+
+//compare the command and arguments, return the mistake or a empty message if they are equivalent
+func (this *CommandMatch) judge(test, ans *MockContainer) Message {
+	testLine := append([]string{test.Command}, test.Arg...)
+	ansLine := append([]string{ans.Command}, ans.Arg...)
+	if test.Command == "" {
+		testLine = nil
+	}
+	if ans.Command == "" {
+		ansLine = nil
+	}
+	if reason := this.validate(); !reason.IsEmpty() {
+		return msg("exercise.config", reason)
+	}
+	var err error
+	if findInArray(this.Strategies, CompareShell) {
+		if testLine, err = shellLine(testLine); err != nil {
+			return toMessage(err)
+		}
+		if ansLine, err = shellLine(ansLine); err != nil {
+			return msg("exercise.answer", err)
+		}
+	}
+	if findInArray(this.Strategies, CompareBasename) {
+		testLine, ansLine = basenameLine(testLine), basenameLine(ansLine)
+	}
+	if findInArray(this.Strategies, CompareUnordered) {
+		testLine, ansLine = unorderedLine(testLine), unorderedLine(ansLine)
+	}
+	got := strings.Join(testLine, " ")
+	if findInArray(this.Strategies, CompareRegex) {
+		if !regexp.MustCompile("^(?:" + this.Pattern + ")$").MatchString(got) {
+			return msg("judge.command_pattern", got, this.Pattern)
+		}
+		return Message{}
+	}
+	if expect := strings.Join(ansLine, " "); got != expect {
+		if expect == "" {
+			return msg("judge.unexpect_command", got)
+		}
+		return msg("judge.command", expect, got)
+	}
+	return Message{}
+}
+
+//a word that need no quotes in shell
+var plainWordReg = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+//remove the quotes of each token, and split the script of sh -c into words,
+//each token is kept apart and the script is written back as one quoted token,
+//so sh -c 'echo a b' is not the same as sh -c echo a b which pass a and b to the script
+func shellLine(line []string) ([]string, error) {
+	result := []string{}
+	for _, token := range line {
+		word, err := unquote(token)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, quoteWord(word))
+	}
+	if isShellScript(line) {
+		script, _ := unquote(line[2])
+		words, err := shellWords(script)
+		if err != nil {
+			return nil, err
+		}
+		result[2] = quoteWord(quoteWords(words))
+	}
+	return result, nil
+}
+
+//change the program of a command line and the program of the script of sh -c into its name, such as /bin/sh into sh
+func basenameLine(line []string) []string {
+	result := append([]string{}, line...)
+	if len(result) > 0 {
+		result[0] = path.Base(result[0])
+	}
+	if isShellScript(result) {
+		result[2] = mapScript(result[2], func(words []string) []string {
+			words[0] = path.Base(words[0])
+			return words
+		})
+	}
+	return result
+}
+
+//check if a command line run a script by sh -c, the script is the word after -c
+func isShellScript(line []string) bool {
+	return len(line) > 2 && line[1] == "-c" && findInArray(shellPrograms, path.Base(line[0]))
+}
+
+//change the words of a script that written as a token, the token is not changed if it is not a script of words
+func mapScript(token string, change func([]string) []string) string {
+	script, err := unquote(token)
+	if err != nil {
+		return token
+	}
+	words, err := shellWords(script)
+	if err != nil || len(words) == 0 {
+		return token
+	}
+	return quoteWord(quoteWords(change(words)))
+}
+
+//remove the quotes and escapes of a token, such as 'a b' into a b
+func unquote(token string) (string, error) {
+	words, err := shellWords(token)
+	return strings.Join(words, " "), err
+}
+
+//quote a word for shell if it has blanks or special characters
+func quoteWord(word string) string {
+	if plainWordReg.MatchString(word) {
+		return word
+	}
+	return "'" + strings.Replace(word, "'", `'\''`, -1) + "'"
+}
+
+//quote each word and join them into a command line
+func quoteWords(words []string) string {
+	quoted := []string{}
+	for _, word := range words {
+		quoted = append(quoted, quoteWord(word))
+	}
+	return strings.Join(quoted, " ")
+}
+
+//sort the options of a command line, a long option without '=' or a single short option take the next word as its argument,
+//so ping -c 3 -i 1 and ping -i 1 -c 3 are the same but ping -i 3 -c 1 is not,
+//the program and the words before the first option such as the subcommand keep their place,
+//the other words follow the options in their order, the options of the script of sh -c are sorted instead
+func unorderedLine(line []string) []string {
+	if isShellScript(line) { //sort the options of the script
+		result := append([]string{}, line...)
+		result[2] = mapScript(line[2], unorderedLine)
+		return result
+	}
+	first := len(line)
+	for i := 1; i < len(line); i++ {
+		if strings.HasPrefix(line[i], "-") {
+			first = i
+			break
+		}
+	}
+	options, words := []string{}, []string{}
+	for i := first; i < len(line); i++ {
+		switch {
+		case (strings.HasPrefix(line[i], "--") && !strings.Contains(line[i], "=") || len(line[i]) == 2 && line[i][0] == '-') && i+1 < len(line) && !strings.HasPrefix(line[i+1], "-"):
+			options = append(options, line[i]+" "+line[i+1])
+			i++
+		case strings.HasPrefix(line[i], "-"):
+			options = append(options, line[i])
+		default:
+			words = append(words, line[i])
+		}
+	}
+	sort.Strings(options)
+	return append(append(append([]string{}, line[:first]...), options...), words...)
+}
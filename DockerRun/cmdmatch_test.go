@@ -0,0 +1,71 @@
+package DockerRun
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommandMatch(t *testing.T) {
+	testCase := []struct {
+		match CommandMatch
+		ans   string
+		pass  []string
+		fail  []string
+	}{
+		{CommandMatch{Strategies: []string{CompareShell}}, `docker run ubuntu sh -c 'pwd'`,
+			[]string{`docker run ubuntu sh -c "pwd"`, `docker run ubuntu sh -c pwd`},
+			[]string{`docker run ubuntu sh -c 'ls'`, `docker run ubuntu /bin/sh -c pwd`}},
+		{CommandMatch{Strategies: []string{CompareShell, CompareBasename}}, `docker run ubuntu /bin/sh -c 'echo hello world'`,
+			[]string{`docker run ubuntu sh -c "echo hello world"`, `docker run ubuntu /bin/sh -c 'echo hello world'`},
+			[]string{`docker run ubuntu bash -c "echo hello world"`, `docker run ubuntu sh -c "echo hello"`, `docker run ubuntu sh -c echo hello world`}},
+		{CommandMatch{Strategies: []string{CompareShell}}, `docker run ubuntu sh -c 'echo a b'`,
+			[]string{`docker run ubuntu sh -c "echo  a b"`, `docker run ubuntu sh -c echo\ a\ b`},
+			[]string{`docker run ubuntu sh -c echo a b`, `docker run ubuntu sh -c 'echo a' b`}},
+		{CommandMatch{Strategies: []string{CompareBasename}}, `docker run ubuntu sh -c '/bin/ls /tmp'`,
+			[]string{`docker run ubuntu /bin/sh -c 'ls /tmp'`},
+			[]string{`docker run ubuntu sh -c 'rm -rf /var/tmp'`, `docker run ubuntu sh -c '/bin/ls /var/tmp'`}},
+		{CommandMatch{Strategies: []string{CompareUnordered}}, `docker run node ng build --prod --aot`,
+			[]string{`docker run node ng build --aot --prod`},
+			[]string{`docker run node ng --prod build --aot`, `docker run node ng build --prod`}},
+		{CommandMatch{Strategies: []string{CompareShell, CompareUnordered}}, `docker run ubuntu sh -c 'ls -a -l'`,
+			[]string{`docker run ubuntu sh -c "ls -l -a"`},
+			[]string{`docker run ubuntu sh -c "ls -a"`}},
+		{CommandMatch{Strategies: []string{CompareUnordered}}, `docker run busybox ping -c 3 -i 1 host`,
+			[]string{`docker run busybox ping -i 1 -c 3 host`},
+			[]string{`docker run busybox ping -i 3 -c 1 host`, `docker run busybox ping -c 3 -i 2 host`}},
+		{CommandMatch{Strategies: []string{CompareRegex}, Pattern: `ng build (--prod|--configuration production)`}, `docker run node ng build --prod`,
+			[]string{`docker run node ng build --prod`, `docker run node ng build --configuration production`},
+			[]string{`docker run node ng build`, `docker run node ng serve --prod`}},
+	}
+	for i, c := range testCase {
+		exercise := Exercise{Answer: c.ans, Command: c.match}
+		for _, cmd := range c.pass {
+			if res := exercise.Judge(cmd); res != "" {
+				t.Fatalf("Unpass at case %d command %s : %s", i, cmd, res)
+			}
+		}
+		for _, cmd := range c.fail {
+			if res := exercise.Judge(cmd); res == "" {
+				t.Fatalf("worng command %s pass at case %d", cmd, i)
+			}
+		}
+	}
+	exercise := Exercise{Answer: `docker run --name web ubuntu sh -c 'pwd'`, Command: CommandMatch{Strategies: []string{CompareShell}}}
+	if res := exercise.Judge(`docker run ubuntu sh -c "pwd"`); !strings.Contains(res, "ContainerName") {
+		t.Fatalf("the other options should be judged first: %s", res)
+	}
+	if res := exercise.Judge(`docker run --name web ubuntu sh -c "pwd`); !strings.Contains(res, "matching") {
+		t.Fatalf("worng result for unclosed quote: %s", res)
+	}
+	exercise.Command.Strategies = []string{"fuzzy"}
+	if err := exercise.Validate(); err == nil || err.Error() != "The exercise is worng: unknown compare strategy: fuzzy" {
+		t.Fatalf("worng result for unknown strategy: %v", err)
+	}
+	if res := exercise.Judge(`docker run --name web ubuntu sh -c pwd`); !strings.HasPrefix(res, "The exercise is worng") {
+		t.Fatalf("the unknown strategy should not be reported as the mistake of student: %s", res)
+	}
+	exercise.Command = CommandMatch{Strategies: []string{CompareRegex}, Pattern: `sh -c (`}
+	if err := exercise.Validate(); err == nil {
+		t.Fatalf("worng pattern pass")
+	}
+}
@@ -0,0 +1,190 @@
+package DockerRun
+
+//the sub commands of 'docker container', map to the same command in the short form
+var containerManagementActions = map[string]string{
+	"run": "run", "create": "create", "exec": "exec", "ls": "ps", "list": "ps", "ps": "ps",
+	"stop": "stop", "start": "start", "restart": "restart", "kill": "kill", "rm": "rm", "remove": "rm",
+	"logs": "logs", "inspect": "inspect",
+}
+
+//change the management command form into the short form, such as 'docker container run' into 'docker run',
+//'docker container ls' into 'docker ps' and 'docker image build' into 'docker build',
+//the other image management commands are explained by MockImage itself
+func normalizeCommand(cmd []string) []string {
+	if len(cmd) < 3 || !findInArray(SupportedCLIs, cmd[0]) {
+		return cmd
+	}
+	action := ""
+	switch cmd[1] {
+	case "container":
+		action = containerManagementActions[cmd[2]]
+	case "image", "builder":
+		if cmd[2] == "build" {
+			action = "build"
+		}
+	}
+	if action == "" {
+		return cmd
+	}
+	return append([]string{cmd[0], action}, cmd[3:]...)
+}
+
+//return the kind of a docker command: run, build, exec, lifecycle, image, network, volume,
+//or a empty string if the command can not be recognized
+func commandKind(dockerCmd string) string {
+	cmd, reason := stripGlobalOptions(splitCommand(dockerCmd), &ClientConfig{})
+	if !reason.IsEmpty() || len(cmd) < 2 || !findInArray(SupportedCLIs, cmd[0]) {
+		return ""
+	}
+	cmd = normalizeCommand(cmd)
+	if _, have := LifecycleFlags[cmd[1]]; have {
+		return "lifecycle"
+	}
+	if _, have := ImageFlags[cmd[1]]; have {
+		return "image"
+	}
+	switch cmd[1] {
+	case "run", "create":
+		return "run"
+	case "build", "buildx":
+		return "build"
+	case "exec", "image", "network", "volume":
+		return cmd[1]
+	}
+	return ""
+}
+
+//judge a docker command of any kind by compared to the answer,
+//return a string to describe the mistake or a null string if it command is accepted
+func JudgeCommand(test, ans string) string {
+	return judgeCommand(test, ans).String()
+}
+
+//judge a docker command of any kind, return the mistake or a empty message if it command is accepted
+func judgeCommand(test, ans string) Message {
+	kind := commandKind(ans)
+	if kind == "" {
+		return msg("exercise.answer", msg("command.unknown", ans))
+	}
+	if testKind := commandKind(test); testKind != kind {
+		return msg("command.kind", kind, test)
+	}
+	switch kind {
+	case "run":
+		a, err := NewMockContainer(ans)
+		t, err2 := NewMockContainer(test)
+		if reason := parseError(err, err2); !reason.IsEmpty() {
+			return reason
+		}
+		return judge(&t, &a)
+	case "build":
+		a, err := NewMockBuild(ans)
+		t, err2 := NewMockBuild(test)
+		if reason := parseError(err, err2); !reason.IsEmpty() {
+			return reason
+		}
+		return judgeBuild(&t, &a)
+	case "exec":
+		a, err := NewMockExec(ans)
+		t, err2 := NewMockExec(test)
+		if reason := parseError(err, err2); !reason.IsEmpty() {
+			return reason
+		}
+		return judgeExec(&t, &a)
+	case "lifecycle":
+		a, err := NewMockLifecycle(ans)
+		t, err2 := NewMockLifecycle(test)
+		if reason := parseError(err, err2); !reason.IsEmpty() {
+			return reason
+		}
+		return judgeLifecycle(&t, &a)
+	case "image":
+		a, err := NewMockImage(ans)
+		t, err2 := NewMockImage(test)
+		if reason := parseError(err, err2); !reason.IsEmpty() {
+			return reason
+		}
+		return judgeImage(&t, &a)
+	case "network":
+		a, err := NewMockNetwork(ans)
+		t, err2 := NewMockNetwork(test)
+		if reason := parseError(err, err2); !reason.IsEmpty() {
+			return reason
+		}
+		return judgeNetwork(&t, &a)
+	default:
+		a, err := NewMockVolume(ans)
+		t, err2 := NewMockVolume(test)
+		if reason := parseError(err, err2); !reason.IsEmpty() {
+			return reason
+		}
+		return judgeVolume(&t, &a)
+	}
+}
+
+//return the reason of a failed parsing of the answer or the test
+func parseError(ansErr, testErr error) Message {
+	if ansErr != nil {
+		return msg("exercise.answer", toMessage(ansErr))
+	}
+	if testErr != nil {
+		return toMessage(testErr)
+	}
+	return Message{}
+}
+
+//parse a docker command of any kind and apply it to the engine, return the output of the command
+func (this *MockEngine) Apply(dockerCmd string) (string, error) {
+	switch commandKind(dockerCmd) {
+	case "run":
+		ctr, err := NewMockContainer(dockerCmd)
+		if err != nil {
+			return "", err
+		}
+		c, err := this.Run(&ctr)
+		if err != nil {
+			return "", err
+		}
+		if ctr.IsDetach || ctr.IsCreate {
+			return c.ID + "\n", nil
+		}
+		return c.Logs, nil
+	case "build":
+		build, err := NewMockBuild(dockerCmd)
+		if err != nil {
+			return "", err
+		}
+		return "", this.Build(&build)
+	case "exec":
+		e, err := NewMockExec(dockerCmd)
+		if err != nil {
+			return "", err
+		}
+		return this.Exec(&e)
+	case "lifecycle":
+		m, err := NewMockLifecycle(dockerCmd)
+		if err != nil {
+			return "", err
+		}
+		return this.Manage(&m)
+	case "image":
+		m, err := NewMockImage(dockerCmd)
+		if err != nil {
+			return "", err
+		}
+		return this.ManageImage(&m)
+	case "network":
+		m, err := NewMockNetwork(dockerCmd)
+		if err != nil {
+			return "", err
+		}
+		return this.ManageNetwork(&m)
+	case "volume":
+		m, err := NewMockVolume(dockerCmd)
+		if err != nil {
+			return "", err
+		}
+		return this.ManageVolume(&m)
+	}
+	return "", msg("command.unknown", dockerCmd)
+}
@@ -0,0 +1,61 @@
+package DockerRun
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeCommand(t *testing.T) {
+	expect := map[string]string{
+		`docker container run -d mysql`:   `docker run -d mysql`,
+		`docker container ls -a`:          `docker ps -a`,
+		`docker container remove server1`: `docker rm server1`,
+		`docker image build -t app .`:     `docker build -t app .`,
+		`docker image ls`:                 `docker image ls`,
+		`docker run mysql`:                `docker run mysql`,
+	}
+	for cmd, want := range expect {
+		if got := strings.Join(normalizeCommand(strings.Split(cmd, " ")), " "); got != want {
+			t.Fatalf("normalize '%s' expect '%s' but got '%s'", cmd, want, got)
+		}
+	}
+	if _, err := NewMockExec(`docker container exec -it server1 sh`); err != nil {
+		t.Fatalf("docker container exec fail: %v", err)
+	}
+	if m, err := NewMockLifecycle(`docker container ls -a`); err != nil || m.Action != "ps" {
+		t.Fatalf("docker container ls fail: %v", err)
+	}
+	if _, err := NewMockBuild(`docker image build -t app .`); err != nil {
+		t.Fatalf("docker image build fail: %v", err)
+	}
+}
+
+func TestMockContainerCreate(t *testing.T) {
+	ctr, err := NewMockContainer(`docker container run -d --name server1 mysql`)
+	if err != nil || ctr.IsCreate {
+		t.Fatalf("docker container run fail: %v", err)
+	}
+	for _, cmd := range []string{`docker create --name server1 mysql`, `docker container create --name server1 mysql`} {
+		created, err := NewMockContainer(cmd)
+		if err != nil || !created.IsCreate {
+			t.Fatalf("%s fail: %v", cmd, err)
+		}
+		if Judge(&created, &ctr) == "" {
+			t.Fatalf("%s should not pass a docker run answer", cmd)
+		}
+	}
+	if _, err := NewMockContainer(`docker create -d mysql`); err == nil {
+		t.Fatalf("docker create with -d should not pass")
+	}
+	engine := NewMockEngine()
+	if err := engine.RunCommands([]string{`docker create --name server1 -p 80:80 nginx`}); err != nil {
+		t.Fatalf("create fail: %v", err)
+	}
+	if c := engine.Container("server1"); c.State != StateCreated || len(engine.PublishedPorts()) != 0 {
+		t.Fatalf("a created container should not be started, got %s", c.State)
+	}
+	m, _ := NewMockLifecycle(`docker container start server1`)
+	if _, err := engine.Manage(&m); err != nil || engine.Container("server1").State != StateRunning {
+		t.Fatalf("start a created container fail: %v", err)
+	}
+}
@@ -0,0 +1,334 @@
+package DockerRun
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+//ComposeService is an entry of 'services:' in a docker-compose file, only the keys that can be mapped to MockContainer is supported
+type ComposeService struct {
+	Image         string
+	Command       []string
+	Ports         []string
+	Volumes       []string
+	Environment   map[string]string
+	ContainerName string
+	WorkingDir    string
+	User          string
+	Hostname      string
+	Networks      []string
+	NetworkMode   string
+	MemLimit      string
+	CpuShares     int
+	Restart       string
+	Labels        []string
+	Links         []string
+	StdinOpen     bool
+	Tty           bool
+}
+
+//the network that is set by 'network_mode:' instead of 'networks:' in compose
+var composeNetworkModes = []string{"host", "none", "bridge"}
+
+//convert a container into a compose service, the name of service is the container name or the base name of images
+func (this *MockContainer) ToComposeService() (name string, svc ComposeService) {
+	svc = ComposeService{
+		Image:         this.Images,
+		Environment:   this.Env,
+		ContainerName: this.ContainerName,
+		WorkingDir:    this.WorkDir,
+		User:          this.User,
+		Hostname:      this.HostName,
+		Restart:       this.Restart,
+		CpuShares:     this.CpuShare,
+		Labels:        this.Label,
+		Links:         this.Link,
+		StdinOpen:     this.IsInteractive,
+		Tty:           this.IsTTY,
+	}
+	if this.Command != "" {
+		svc.Command = append([]string{this.Command}, this.Arg...)
+	}
+	for _, hostPort := range sortedKeys(this.Port) {
+		svc.Ports = append(svc.Ports, hostPort+":"+this.Port[hostPort])
+	}
+	for _, source := range sortedKeys(this.Volume) {
+		volume := source + ":" + this.Volume[source]
+		if opts, have := this.VolumeOption[source]; have {
+			volume += ":" + opts
+		}
+		svc.Volumes = append(svc.Volumes, volume)
+	}
+	if findInArray(composeNetworkModes, this.NetWork) {
+		svc.NetworkMode = this.NetWork
+	} else if this.NetWork != "" {
+		svc.Networks = []string{this.NetWork}
+	}
+	if this.Memory != 0 {
+		svc.MemLimit = fmt.Sprintf("%dm", this.Memory)
+	}
+	name = this.ContainerName
+	if name == "" {
+		repo := strings.Split(this.Images, ":")[0]
+		name = repo[strings.LastIndex(repo, "/")+1:]
+	}
+	return name, svc
+}
+
+//generate a docker-compose file that contain only the service converted from the container,
+//the network and named volume is declared as external so that compose use them as the same name as docker run
+func (this *MockContainer) ToCompose() string {
+	name, svc := this.ToComposeService()
+	var sb strings.Builder
+	sb.WriteString("services:\n")
+	sb.WriteString(fmt.Sprintf("  %s:\n", yamlScalar(name)))
+	addStr := func(key, value string) {
+		if value != "" {
+			writeYAMLValue(&sb, key, value, 2)
+		}
+	}
+	addList := func(key string, value []string) {
+		if len(value) > 0 {
+			writeYAMLValue(&sb, key, value, 2)
+		}
+	}
+	addStr("image", svc.Image)
+	addStr("container_name", svc.ContainerName)
+	addList("command", svc.Command)
+	addList("ports", svc.Ports)
+	addList("volumes", svc.Volumes)
+	if len(svc.Environment) > 0 {
+		writeYAMLValue(&sb, "environment", svc.Environment, 2)
+	}
+	addStr("working_dir", svc.WorkingDir)
+	addStr("user", svc.User)
+	addStr("hostname", svc.Hostname)
+	addStr("network_mode", svc.NetworkMode)
+	addList("networks", svc.Networks)
+	addStr("mem_limit", svc.MemLimit)
+	if svc.CpuShares != 0 {
+		writeYAMLValue(&sb, "cpu_shares", svc.CpuShares, 2)
+	}
+	addStr("restart", svc.Restart)
+	addList("labels", svc.Labels)
+	addList("links", svc.Links)
+	if svc.StdinOpen {
+		writeYAMLValue(&sb, "stdin_open", true, 2)
+	}
+	if svc.Tty {
+		writeYAMLValue(&sb, "tty", true, 2)
+	}
+	if len(svc.Networks) > 0 {
+		sb.WriteString("networks:\n")
+		for _, n := range svc.Networks {
+			sb.WriteString(fmt.Sprintf("  %s:\n    external: true\n", yamlScalar(n)))
+		}
+	}
+	volumes := []string{}
+	for _, source := range sortedKeys(this.Volume) {
+		if isNamedVolume(source) {
+			volumes = append(volumes, source)
+		}
+	}
+	if len(volumes) > 0 {
+		sb.WriteString("volumes:\n")
+		for _, v := range volumes {
+			sb.WriteString(fmt.Sprintf("  %s:\n    external: true\n", yamlScalar(v)))
+		}
+	}
+	return sb.String()
+}
+
+//create an MockContainer according to a service of docker-compose file,
+//service can be empty if there is only one service in the file, return error if the file or the service have a worng syntax
+func NewMockContainerFromCompose(compose, service string) (model MockContainer, err error) {
+	model.Port = make(map[string]string)
+	model.Volume = make(map[string]string)
+	model.Env = make(map[string]string)
+	doc, err := parseYAML(compose)
+	if err != nil {
+		return model, err
+	}
+	root, ok := doc.(map[string]interface{})
+	if !ok {
+		return model, fmt.Errorf("Compose file should be a mapping")
+	}
+	services, ok := root["services"].(map[string]interface{})
+	if !ok || len(services) == 0 {
+		return model, fmt.Errorf("Can't find services from compose file!")
+	}
+	if service == "" {
+		if len(services) != 1 {
+			return model, fmt.Errorf("Compose file have %d services, service name is required", len(services))
+		}
+		for name := range services {
+			service = name
+		}
+	}
+	svc, ok := services[service].(map[string]interface{})
+	if !ok {
+		return model, fmt.Errorf("Can't find service %s from compose file!", service)
+	}
+	err = model.loadComposeService(svc)
+	return model, err
+}
+
+//setting up the property of a container according to a compose service, the value is checked by HandleArgument()
+func (this *MockContainer) loadComposeService(svc map[string]interface{}) error {
+	this.isCompose = true
+	keys := []string{}
+	for k := range svc {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := svc[key]
+		var err error
+		switch key {
+		case "image":
+			image := composeString(value)
+			if !isImagesName(image) {
+				return fmt.Errorf("Images name %s not legal!", image)
+			}
+			this.Images = withDefaultTag(image)
+			this.IsTagged = hasImageTag(image)
+		case "command":
+			cmd := composeList(value)
+			if str, isStr := value.(string); isStr {
+				cmd = splitCommand(str)
+				for i := range cmd {
+					cmd[i] = trimStr(cmd[i])
+				}
+			}
+			if len(cmd) > 0 && cmd[0] != "" {
+				this.Command = cmd[0]
+				if len(cmd) > 1 {
+					this.Arg = cmd[1:]
+				}
+			}
+		case "ports":
+			err = this.handleComposeList("p", value)
+		case "volumes":
+			volumes := []string{}
+			for _, v := range composeList(value) {
+				volumes = append(volumes, normalizeComposePath(v))
+			}
+			err = this.handleComposeList("v", volumes)
+		case "environment":
+			err = this.handleComposeList("e", composePairs(value))
+		case "labels":
+			err = this.handleComposeList("l", composePairs(value))
+		case "links":
+			err = this.handleComposeList("link", value)
+		case "container_name":
+			err = this.HandleArgument("name", composeString(value))
+		case "working_dir":
+			err = this.HandleArgument("w", composeString(value))
+		case "user":
+			err = this.HandleArgument("u", composeString(value))
+		case "hostname":
+			err = this.HandleArgument("h", composeString(value))
+		case "network_mode":
+			err = this.HandleArgument("network", composeString(value))
+		case "networks":
+			networks := composeList(value)
+			if m, isMap := value.(map[string]interface{}); isMap {
+				networks = []string{}
+				for name := range m {
+					networks = append(networks, name)
+				}
+			}
+			if len(networks) > 1 {
+				return fmt.Errorf("Only one network can be connected by docker run, but got %v", networks)
+			}
+			err = this.handleComposeList("network", networks)
+		case "mem_limit":
+			err = this.HandleArgument("m", composeString(value))
+		case "cpu_shares":
+			err = this.HandleArgument("c", composeString(value))
+		case "restart":
+			err = this.HandleArgument("restart", composeString(value))
+		case "stdin_open":
+			this.IsInteractive = composeString(value) == "true"
+		case "tty":
+			this.IsTTY = composeString(value) == "true"
+		default:
+			return fmt.Errorf("Unsupported compose key: %s", key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if this.Images == "" {
+		return fmt.Errorf("Can't find images name from compose service!")
+	}
+	return nil
+}
+
+//call HandleArgument() for each element of a list value
+func (this *MockContainer) handleComposeList(flag string, value interface{}) error {
+	list, isStrs := value.([]string)
+	if !isStrs {
+		list = composeList(value)
+	}
+	for _, item := range list {
+		if err := this.HandleArgument(flag, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//return the string of a scalar value
+func composeString(value interface{}) string {
+	if str, ok := value.(string); ok {
+		return str
+	}
+	return ""
+}
+
+//return the elements of a list value, a scalar is seen as a list with one element
+func composeList(value interface{}) []string {
+	result := []string{}
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			result = append(result, composeString(item))
+		}
+	case string:
+		if v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+//return the 'KEY=VALUE' list of environment or labels, both mapping and list syntax are accepted
+func composePairs(value interface{}) []string {
+	m, isMap := value.(map[string]interface{})
+	if !isMap {
+		return composeList(value)
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys) //the order of a mapping is lost, sort them so that the result is stable
+	result := []string{}
+	for _, k := range keys {
+		result = append(result, k+"="+composeString(m[k]))
+	}
+	return result
+}
+
+//change the path variable used in compose file into the $PWD used in docker run, such as ${PWD}/data or ./data
+func normalizeComposePath(volume string) string {
+	volume = strings.Replace(volume, "${PWD}", "$PWD", 1)
+	relativeReg := regexp.MustCompile(`^\.(/|:)`)
+	if relativeReg.MatchString(volume) {
+		volume = "$PWD" + volume[1:]
+	}
+	return volume
+}
@@ -0,0 +1,72 @@
+package DockerRun
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComposeConversion(t *testing.T) {
+	for _, cmd := range usingExample {
+		ctr, _ := NewMockContainer(cmd)
+		compose := ctr.ToCompose()
+		back, err := NewMockContainerFromCompose(compose, "")
+		if err != nil {
+			t.Fatalf("load generated compose of '%s' fail: %v\n%s", cmd, err, compose)
+		}
+		back.IsDetach, back.IsRemove = ctr.IsDetach, ctr.IsRemove //not a property of compose service
+		if res := Judge(&back, &ctr); res != "" {
+			t.Fatalf("compose of '%s' not equal to the command: %s\n%s", cmd, res, compose)
+		}
+	}
+}
+
+func TestNewMockContainerFromCompose(t *testing.T) {
+	compose := `
+version: "3"
+services:
+  db:
+    image: postgres
+  web:
+    image: username/1000010021_server   # the answer of lesson 21
+    command: serve --port "8080"
+    ports:
+    - "8081:8080"
+    volumes:
+      - ./data:/data
+      - username_vol:/var/lib/data
+    environment:
+      MODE: prod
+    working_dir: /data
+    mem_limit: 1g
+    restart: unless-stopped
+    networks: [netname]
+`
+	web, err := NewMockContainerFromCompose(compose, "web")
+	if err != nil {
+		t.Fatalf("load compose fail: %v", err)
+	}
+	ans, _ := NewMockContainer(`docker run -m 1024m -w /data -e MODE=prod -p 8081:8080 -v $PWD/data:/data -v username_vol:/var/lib/data --restart unless-stopped --network netname username/1000010021_server serve --port 8080`)
+	if res := Judge(&web, &ans); res != "" || web.Env["MODE"] != "prod" || web.NetWork != "netname" {
+		web.Printf()
+		t.Fatalf("compose service should pass: %s", res)
+	}
+	if _, err := NewMockContainerFromCompose(compose, ""); err == nil {
+		t.Fatalf("service name should be required when there are many services")
+	}
+	if _, err := NewMockContainerFromCompose("services:\n  web:\n    image: alpine\n    privileged: true\n", ""); err == nil {
+		t.Fatalf("unsupported key should return error")
+	}
+}
+
+func TestComposeLabelOrder(t *testing.T) {
+	compose := "services:\n  web:\n    image: nginx\n    labels:\n      tier: web\n      app: shop\n      env: prod\n"
+	for i := 0; i < 10; i++ {
+		web, err := NewMockContainerFromCompose(compose, "web")
+		if err != nil {
+			t.Fatalf("load compose fail: %v", err)
+		}
+		if strings.Join(web.Label, ",") != "app=shop,env=prod,tier=web" {
+			t.Fatalf("labels are not in order: %v", web.Label)
+		}
+	}
+}
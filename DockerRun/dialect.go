@@ -0,0 +1,84 @@
+package DockerRun
+
+import (
+	"regexp"
+	"strings"
+)
+
+//the input dialects can be used by ToBash()
+const (
+	DialectBash       = "bash"
+	DialectPowerShell = "powershell"
+	DialectCmd        = "cmd"
+)
+
+var (
+	//the current directory in PowerShell, such as ${PWD}, $pwd, $(pwd) and $(Get-Location)
+	powerShellPwdReg = regexp.MustCompile(`(?i)\$\{pwd\}|\$\(pwd\)|\$\(get-location\)|\$pwd\b`)
+	//the environment variable in PowerShell, such as $env:HOME
+	powerShellEnvReg = regexp.MustCompile(`(?i)\$env:(\w+)`)
+	//the current directory in cmd.exe
+	cmdPwdReg = regexp.MustCompile(`(?i)%cd%`)
+	//the environment variable in cmd.exe, such as %USERPROFILE%
+	cmdEnvReg = regexp.MustCompile(`%(\w+)%`)
+	//the host path start with $PWD, the windows separator '\' in it is changed into '/'
+	pwdPathReg = regexp.MustCompile(`\$PWD[^\s:"']*`)
+)
+
+//change a command written in the dialect into the equivalent bash command, so that it can be parsed by splitCommand(),
+//the continuation of PowerShell (`) and cmd.exe (^) is changed into a space and the path variables are changed into $PWD
+func ToBash(cmd, dialect string) (string, error) {
+	var escape byte
+	switch dialect {
+	case DialectBash, "":
+		return cmd, nil
+	case DialectPowerShell:
+		escape = '`'
+	case DialectCmd:
+		escape = '^'
+	default:
+		return cmd, msg("dialect.unknown", dialect)
+	}
+	var sb strings.Builder
+	var quote byte
+	for i := 0; i < len(cmd); i++ {
+		c := cmd[i]
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case c == escape && (quote == 0 || (quote == '"' && dialect == DialectPowerShell)):
+			//the escape character is not special in the quotes of cmd.exe and the single quotes of PowerShell
+			j := i + 1
+			for j < len(cmd) && (cmd[j] == ' ' || cmd[j] == '\t' || cmd[j] == '\r') {
+				j++
+			}
+			if j >= len(cmd) || cmd[j] == '\n' { //a continuation
+				sb.WriteByte(' ')
+				i = j
+				continue
+			}
+			if i+1 < len(cmd) { //a escaped character, it is escaped by '\' again if it is special to bash
+				i++
+				c = cmd[i]
+				if (quote == '"' && strings.IndexByte("\"$`\\", c) >= 0) || (quote == 0 && strings.IndexByte(" \t\"'$`\\;&|<>()#", c) >= 0) {
+					sb.WriteByte('\\')
+				}
+			}
+		}
+		sb.WriteByte(c)
+	}
+	result := sb.String()
+	if dialect == DialectPowerShell {
+		result = powerShellPwdReg.ReplaceAllString(result, "$$PWD")
+		result = powerShellEnvReg.ReplaceAllString(result, "$$$1")
+	} else {
+		result = cmdPwdReg.ReplaceAllString(result, "$$PWD")
+		result = cmdEnvReg.ReplaceAllString(result, "$$$1")
+	}
+	result = pwdPathReg.ReplaceAllStringFunc(result, func(path string) string {
+		return strings.Replace(path, "\\", "/", -1)
+	})
+	return result, nil
+}
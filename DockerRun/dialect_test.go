@@ -0,0 +1,35 @@
+package DockerRun
+
+import "testing"
+
+func TestToBash(t *testing.T) {
+	expect := map[string][2]string{
+		"docker run -d `\r\n  -v ${PWD}\\data:/data `\n  -e HOME=$env:HOME mysql":   {DialectPowerShell, "docker run -d    -v $PWD/data:/data    -e HOME=$HOME mysql"},
+		"docker run -v $(Get-Location):/app -e MSG=\"a`\"b\" mysql":                 {DialectPowerShell, "docker run -v $PWD:/app -e MSG=\"a\\\"b\" mysql"},
+		"docker run -e MSG=a`\"b`$c mysql":                                          {DialectPowerShell, "docker run -e MSG=a\\\"b\\$c mysql"},
+		"docker run -d ^\r\n  -v %cd%\\data:/data ^\n  -e HOME=%USERPROFILE% mysql": {DialectCmd, "docker run -d    -v $PWD/data:/data    -e HOME=$USERPROFILE mysql"},
+		"docker run -e \"MSG=a^b\" mysql":                                           {DialectCmd, "docker run -e \"MSG=a^b\" mysql"},
+		"docker run -v ${PWD}:/app mysql":                                           {DialectBash, "docker run -v ${PWD}:/app mysql"},
+	}
+	for cmd, e := range expect {
+		got, err := ToBash(cmd, e[0])
+		if err != nil || got != e[1] {
+			t.Fatalf("%s command %q expect %q but got %q: %v", e[0], cmd, e[1], got, err)
+		}
+	}
+	if _, err := ToBash("docker ps", "fish"); err == nil {
+		t.Fatalf("unknown dialect should not pass")
+	}
+	exercise := Exercise{Answer: `docker run -d -v $PWD/data:/data --name db mysql`, Dialect: DialectPowerShell}
+	if res := exercise.Judge("docker run -d `\n -v ${PWD}\\data:/data `\n --name db mysql"); res != "" {
+		t.Fatalf("PowerShell command should pass: %s", res)
+	}
+	exercise.Dialect = DialectCmd
+	if res := exercise.Judge("docker run -d ^\n -v %cd%/data:/data ^\n --name db mysql"); res != "" {
+		t.Fatalf("cmd command should pass: %s", res)
+	}
+	exercise.Dialect = DialectBash
+	if res := exercise.Judge("docker run -d -v ${PWD}/data:/data --name db mysql"); res != "" {
+		t.Fatalf("bash command with ${PWD} should pass: %s", res)
+	}
+}
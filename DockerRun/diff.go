@@ -0,0 +1,230 @@
+package DockerRun
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+)
+
+//DiffKind describe how a property of the submission differ from the answer
+type DiffKind int
+
+const (
+	DiffAdded   DiffKind = iota //only found in the submission
+	DiffMissing                 //only found in the answer
+	DiffChanged                 //found in both but with different value
+)
+
+//ANSI color code used when render a diff for terminal
+const (
+	colorRed   = "\033[31m"
+	colorGreen = "\033[32m"
+	colorReset = "\033[0m"
+)
+
+//DiffItem is one difference between two containers, Key is set only for map or list property such as Port and Env
+type DiffItem struct {
+	Field  string
+	Key    string
+	Kind   DiffKind
+	Test   string
+	Answer string
+}
+
+//return the name of a DiffKind
+func (this DiffKind) String() string {
+	switch this {
+	case DiffAdded:
+		return "added"
+	case DiffMissing:
+		return "missing"
+	case DiffChanged:
+		return "changed"
+	}
+	return "unknown"
+}
+
+//return the label of the property, such as 'Port 8080' or 'Images'
+func (this DiffItem) Name() string {
+	if this.Key == "" {
+		return this.Field
+	}
+	return this.Field + " " + this.Key
+}
+
+//compare all the property of two containers and return the differences,
+//unlike Judge() it do not stop at the first mistake and it also compare Env[], Label[], Attach[], Link[]
+func Diff(test, ans *MockContainer) []DiffItem {
+	items := []DiffItem{}
+	if test == nil || ans == nil {
+		return items
+	}
+	diffStr := func(field, t, a string) {
+		switch {
+		case t == a:
+		case a == "":
+			items = append(items, DiffItem{Field: field, Kind: DiffAdded, Test: t})
+		case t == "":
+			items = append(items, DiffItem{Field: field, Kind: DiffMissing, Answer: a})
+		default:
+			items = append(items, DiffItem{Field: field, Kind: DiffChanged, Test: t, Answer: a})
+		}
+	}
+	diffInt := func(field string, t, a int) {
+		if t != a {
+			items = append(items, DiffItem{Field: field, Kind: DiffChanged, Test: fmt.Sprint(t), Answer: fmt.Sprint(a)})
+		}
+	}
+	diffBool := func(field string, t, a bool) {
+		if t && !a {
+			items = append(items, DiffItem{Field: field, Kind: DiffAdded, Test: "true"})
+		} else if !t && a {
+			items = append(items, DiffItem{Field: field, Kind: DiffMissing, Answer: "true"})
+		}
+	}
+	diffMap := func(field string, t, a map[string]string) {
+		keys := []string{}
+		for k := range a {
+			keys = append(keys, k)
+		}
+		for k := range t {
+			if _, have := a[k]; !have {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			tv, inTest := t[k]
+			av, inAns := a[k]
+			switch {
+			case inTest && !inAns:
+				items = append(items, DiffItem{Field: field, Key: k, Kind: DiffAdded, Test: tv})
+			case !inTest && inAns:
+				items = append(items, DiffItem{Field: field, Key: k, Kind: DiffMissing, Answer: av})
+			case tv != av:
+				items = append(items, DiffItem{Field: field, Key: k, Kind: DiffChanged, Test: tv, Answer: av})
+			}
+		}
+	}
+	diffList := func(field string, t, a []string) {
+		for _, v := range a {
+			if !findInArray(t, v) {
+				items = append(items, DiffItem{Field: field, Kind: DiffMissing, Answer: v})
+			}
+		}
+		for _, v := range t {
+			if !findInArray(a, v) {
+				items = append(items, DiffItem{Field: field, Kind: DiffAdded, Test: v})
+			}
+		}
+	}
+	diffStr("Images", test.Images, ans.Images)
+	diffStr("Command", test.Command, ans.Command)
+	diffStr("Arg", strings.Join(test.Arg, " "), strings.Join(ans.Arg, " "))
+	diffStr("HostName", test.HostName, ans.HostName)
+	diffStr("ContainerName", test.ContainerName, ans.ContainerName)
+	diffStr("User", test.User, ans.User)
+	diffStr("WorkDir", test.WorkDir, ans.WorkDir)
+	diffStr("NetWork", test.NetWork, ans.NetWork)
+	diffStr("Restart", test.Restart, ans.Restart)
+	diffStr("Pod", test.Pod, ans.Pod)
+	diffStr("UserNS", test.UserNS, ans.UserNS)
+	diffStr("Platform", test.Platform, ans.Platform)
+	diffStr("Pull", test.Pull, ans.Pull)
+	diffStr("Gpus", test.Gpus, ans.Gpus)
+	diffStr("Pid", test.Pid, ans.Pid)
+	diffStr("CapAdd", strings.Join(test.CapAdd, " "), strings.Join(ans.CapAdd, " "))
+	diffStr("CapDrop", strings.Join(test.CapDrop, " "), strings.Join(ans.CapDrop, " "))
+	diffStr("Device", strings.Join(test.Device, " "), strings.Join(ans.Device, " "))
+	diffStr("Mount", strings.Join(test.Mount, " "), strings.Join(ans.Mount, " "))
+	diffStr("Context", test.Client.Context, ans.Client.Context)
+	diffStr("Host", strings.Join(test.Client.Hosts, " "), strings.Join(ans.Client.Hosts, " "))
+	diffInt("CpuShare", test.CpuShare, ans.CpuShare)
+	diffInt("Memory", test.Memory, ans.Memory)
+	diffBool("IsRemove", test.IsRemove, ans.IsRemove)
+	diffBool("IsDetach", test.IsDetach, ans.IsDetach)
+	diffBool("IsTTY", test.IsTTY, ans.IsTTY)
+	diffBool("IsInteractive", test.IsInteractive, ans.IsInteractive)
+	diffBool("IsPublishAll", test.IsPublishAll, ans.IsPublishAll)
+	diffBool("IsCreate", test.IsCreate, ans.IsCreate)
+	diffBool("IsPrivileged", test.IsPrivileged, ans.IsPrivileged)
+	diffMap("Port", test.Port, ans.Port)
+	diffMap("Volume", test.Volume, ans.Volume)
+	diffMap("VolumeOption", test.VolumeOption, ans.VolumeOption)
+	diffMap("Env", test.Env, ans.Env)
+	diffList("EnvFromHost", test.EnvFromHost, ans.EnvFromHost)
+	diffList("Label", test.Label, ans.Label)
+	diffList("Attach", test.Attach, ans.Attach)
+	diffList("Link", test.Link, ans.Link)
+	return items
+}
+
+//render the differences in unified format, '-' for what the answer expect and '+' for what the submission have
+//set color to true to wrap the lines with ANSI color code for terminal
+func RenderDiff(items []DiffItem, color bool) string {
+	var sb strings.Builder
+	line := func(sign, name, value, code string) {
+		if color {
+			sb.WriteString(code)
+		}
+		sb.WriteString(fmt.Sprintf("%s %s: %s", sign, name, value))
+		if color {
+			sb.WriteString(colorReset)
+		}
+		sb.WriteString("\n")
+	}
+	for _, item := range items {
+		if item.Kind != DiffAdded {
+			line("-", item.Name(), item.Answer, colorRed)
+		}
+		if item.Kind != DiffMissing {
+			line("+", item.Name(), item.Test, colorGreen)
+		}
+	}
+	return sb.String()
+}
+
+//render the differences in side-by-side format with the answer on the left and the submission on the right
+func RenderDiffSideBySide(items []DiffItem, color bool) string {
+	nameWidth, valueWidth := len("Property"), len("Answer")
+	for _, item := range items {
+		if len(item.Name()) > nameWidth {
+			nameWidth = len(item.Name())
+		}
+		if len(item.Answer) > valueWidth {
+			valueWidth = len(item.Answer)
+		}
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("  %-*s | %-*s | %s\n", nameWidth, "Property", valueWidth, "Answer", "Submission"))
+	for _, item := range items {
+		sign, code := "~", ""
+		switch item.Kind {
+		case DiffAdded:
+			sign, code = "+", colorGreen
+		case DiffMissing:
+			sign, code = "-", colorRed
+		}
+		row := fmt.Sprintf("%s %-*s | %-*s | %s", sign, nameWidth, item.Name(), valueWidth, item.Answer, item.Test)
+		if color && code != "" {
+			row = code + row + colorReset
+		}
+		sb.WriteString(row + "\n")
+	}
+	return sb.String()
+}
+
+//render the differences as a html table for the web page,
+//each row have a class 'diff-added', 'diff-missing' or 'diff-changed' so that the style can be set by css
+func RenderDiffHTML(items []DiffItem) string {
+	var sb strings.Builder
+	sb.WriteString("<table class=\"diff\">\n")
+	sb.WriteString("<tr><th>Property</th><th>Answer</th><th>Submission</th></tr>\n")
+	for _, item := range items {
+		sb.WriteString(fmt.Sprintf("<tr class=\"diff-%s\"><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+			item.Kind, html.EscapeString(item.Name()), html.EscapeString(item.Answer), html.EscapeString(item.Test)))
+	}
+	sb.WriteString("</table>\n")
+	return sb.String()
+}
@@ -0,0 +1,39 @@
+package DockerRun
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	ans, _ := NewMockContainer(`docker run --rm -it -p 8080:80 -v /hello:/world --name web images:latest`)
+	test, _ := NewMockContainer(`docker run -it -p 8080:81 -p 9090:90 --name web images:latest`)
+	items := Diff(&test, &ans)
+	expect := map[string]DiffKind{
+		"IsRemove":      DiffMissing,
+		"Port 8080":     DiffChanged,
+		"Port 9090":     DiffAdded,
+		"Volume /hello": DiffMissing,
+	}
+	if len(items) != len(expect) {
+		t.Fatalf("expect %d differences but got %d: %v", len(expect), len(items), items)
+	}
+	for _, item := range items {
+		if kind, have := expect[item.Name()]; !have || kind != item.Kind {
+			t.Fatalf("unexpect difference: %v", item)
+		}
+	}
+	if len(Diff(&ans, &ans)) != 0 {
+		t.Fatalf("same container should have no difference")
+	}
+	text := RenderDiff(items, false)
+	if !strings.Contains(text, "- Port 8080: 80\n+ Port 8080: 81\n") {
+		t.Fatalf("unexpect unified diff:\n%s", text)
+	}
+	if !strings.Contains(RenderDiff(items, true), colorRed) {
+		t.Fatalf("color diff should contain ANSI code")
+	}
+	if !strings.Contains(RenderDiffHTML(items), `<tr class="diff-added"><td>Port 9090</td>`) {
+		t.Fatalf("unexpect html diff:\n%s", RenderDiffHTML(items))
+	}
+}
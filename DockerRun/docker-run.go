@@ -1,7 +1,7 @@
 package DockerRun
 
 import (
-	"fmt"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -19,6 +19,18 @@ import (
 --name
 -w, --workdir
 --link
+--restart
+--userns
+--platform
+--pull
+--gpus
+--mount
+--kernel-memory
+--privileged
+--pid
+--cap-add
+--cap-drop
+--device
 -m, --memory
 -i, --interactive
 -d, --detach
@@ -33,9 +45,13 @@ var (
 	SimpleFlagList = []string{"p", "P", "v", "i", "d", "t", "w", "u", "a", "c", "e", "h", "l", "u", "m"}
 	//multiFlagList is those flag start with '--', such as --volume, --link
 	MultiFlagList = []string{"publish-all", "tty", "rm", "detach", "interactive", "link", "workdir",
-		"name", "volume", "user", "label", "hostname", "env", "cpu-shares", "attach", "memory", "network"}
+		"name", "volume", "user", "label", "hostname", "env", "cpu-shares", "attach", "memory", "network",
+		"restart", "userns", "platform", "pull", "gpus", "mount", "kernel-memory", "privileged", "pid",
+		"cap-add", "cap-drop", "device"}
 	//NoArgFlagList is those flag attach with no arguments, such as -d, -i, --rm, note that P and p is different!
-	NoArgFlagList = []string{"i", "interactive", "t", "tty", "d", "detach", "rm", "P", "publish-all"}
+	NoArgFlagList = []string{"i", "interactive", "t", "tty", "d", "detach", "rm", "P", "publish-all", "privileged"}
+	//RunFlags is the flags of docker run
+	RunFlags = FlagSet{SimpleFlagList, MultiFlagList, NoArgFlagList}
 )
 
 //the model to simulate a container property
@@ -45,7 +61,9 @@ type MockContainer struct {
 	Arg           []string
 	Port          map[string]string
 	Volume        map[string]string
+	VolumeOption  map[string]string //the options of a volume such as ro, the key is the same as Volume
 	Env           map[string]string
+	EnvFromHost   []string //the variables without a value such as -e HOME, their values are taken from the host
 	Label         []string
 	CpuShare      int
 	Memory        int
@@ -54,13 +72,33 @@ type MockContainer struct {
 	User          string
 	WorkDir       string
 	NetWork       string
+	Restart       string
+	CLI           string //the command line tool such as podman, it is empty for docker
+	Pod           string
+	UserNS        string
+	Platform      string
+	Pull          string
+	Gpus          string
+	Mount         []string //the mounts that can't be written as -v, such as type=tmpfs,target=/tmp
+	KernelMemory  string
+	Pid           string
+	CapAdd        []string //the capabilities in upper case without CAP_, such as SYS_ADMIN
+	CapDrop       []string
+	Device        []string
 	IsRemove      bool
 	IsDetach      bool
 	IsTTY         bool
 	IsInteractive bool
 	IsPublishAll  bool
+	IsCreate      bool //created by docker create, the container is not started
+	IsPrivileged  bool
+	IsTagged      bool //the tag of images is written in the command, otherwise latest is added
 	Attach        []string
 	Link          []string
+	Client        ClientConfig //the global options before the subcommand
+	Warnings      []Message    //the deprecation warnings found when parsing, such as the legacy --link
+	isCompose     bool         //loaded from a compose service, which has no --rm
+	version       string       //the docker version that the command is parsed for, such as 20.10
 }
 
 //create an MockContainer according to a docker run command, return error if it command have a worng syntax
@@ -69,148 +107,80 @@ func NewMockContainer(dockerCmd string) (model MockContainer, err error) {
 	model.Volume = make(map[string]string)
 	model.Env = make(map[string]string)
 	cmdArray := splitCommand(dockerCmd)
-	result := model.BasicCheck(cmdArray)
-	if result == "" {
-		return model, nil
+	if reason := model.basicCheck(cmdArray); !reason.IsEmpty() {
+		return model, reason
 	}
-	return model, fmt.Errorf(result)
+	return model, nil
 }
 
 //check the basic syntax of a docker run command,
 //return the fall reason or return a empty string if the command is accpeted
 //synatax: docker run [OPTIONS] IMAGE [COMMAND] [ARG...]
 func (this *MockContainer) BasicCheck(cmd []string) string {
-	var err error
+	return this.basicCheck(cmd).String()
+}
+
+//check the basic syntax of a docker run command, return the fall reason or a empty message if the command is accpeted
+func (this *MockContainer) basicCheck(cmd []string) Message {
 	if len(cmd) == 0 {
-		return "Receive empty command!"
+		return msg("run.empty")
 	}
 	if len(cmd) < 2 {
-		return "Requires at least two element!"
+		return msg("run.too_short")
+	}
+	if !findInArray(SupportedCLIs, cmd[0]) {
+		return msg("run.not_docker")
 	}
-	if cmd[0] != "docker" {
-		return "Not a docker command!"
+	if cmd[0] != CLIDocker {
+		this.CLI = cmd[0]
 	}
-	if cmd[1] != "run" {
-		return "Not a run command!"
+	cmd, reason := stripGlobalOptions(cmd, &this.Client)
+	if !reason.IsEmpty() {
+		return reason
 	}
+	cmd = normalizeCommand(cmd)
+	if cmd[1] != "run" && cmd[1] != "create" {
+		return msg("run.not_run")
+	}
+	this.IsCreate = cmd[1] == "create"
 	//begain to explain option part
-	nowAt := 1
-	for {
-		nowAt++
-		if nowAt >= len(cmd) {
-			break
-		}
-		tflag := cmd[nowAt]
-		arg := ""
-		if strings.HasPrefix(tflag, "--") { //scuh as --rm --volume
-			flag := strings.TrimLeft(tflag, "--")
-			if index := strings.Index(flag, "="); index > 0 { //have a '=', such as --volume=test --rm=true
-				if index+1 == len(flag) { //no argument following '=', such as 'rm='
-					return fmt.Sprintf("Unexpect flag: %s", tflag)
-				}
-				arg = flag[index+1:]
-				flag = flag[0:index]
-			}
-			if !findInArray(MultiFlagList, flag) {
-				return fmt.Sprintf("Unknown flag: %s", tflag)
-			}
-			if findInArray(NoArgFlagList, flag) { //don't need argument by default, such as --rm --tty
-				if arg == "" || arg == "true" {
-					err = this.HandleFlag(flag)
-					if err != nil {
-						return fmt.Sprint(err)
-					}
-				} else if arg != "false" {
-					return fmt.Sprintf("Unexpect flag and argument: %s=%s", flag, arg)
-				}
-			} else { //need a argument, such as --name
-				if arg != "" { //--name=hello
-					err = this.HandleArgument(flag, arg)
-				} else { //--name hello
-					nowAt++
-					if len(cmd) <= nowAt {
-						return fmt.Sprintf("Not enough of argument after %s", tflag)
-					}
-					err = this.HandleArgument(flag, cmd[nowAt])
-				}
-				if err != nil {
-					return fmt.Sprint(err)
-				}
-			}
-		} else if strings.HasPrefix(tflag, "-") { //such as -p -d
-			flags := strings.TrimLeft(tflag, "-")
-			for i := 0; i < len(flags); i++ {
-				arg := ""
-				flag := flags[i : i+1]
-				if !findInArray(SimpleFlagList, flag) {
-					return fmt.Sprintf("unknown shorthand flag: %s in %s ", flag, flags[i+1:])
-				}
-				if findInArray(NoArgFlagList, flag) { //do not have argument by default, like -p -t
-					if i+2 < len(flags) && flags[i+1] == '=' { //-t=true
-						arg = flags[i+2:]
-						i = len(flags)
-					}
-					if arg == "" || arg == "true" {
-						err = this.HandleFlag(flag)
-						if err != nil {
-							return fmt.Sprint(err)
-						}
-					} else if arg != "false" {
-						return fmt.Sprintf("Unexpect argument: %s=%s", flag, arg)
-					}
-				} else {
-					if i+1 < len(flags) { //such as -ip8080:8080 or -ip=8080:8080
-						arg = trimStr(flags[i+1:])
-						if strings.HasPrefix(arg, "=") {
-							arg = arg[1:]
-						}
-						err = this.HandleArgument(flag, arg)
-						if err != nil {
-							return fmt.Sprint(err)
-						}
-						break
-					} else { //such as -ip 8080:8080
-						nowAt++
-						if nowAt >= len(cmd) {
-							return fmt.Sprintf("Not enough of argument after -%s", flag)
-						}
-						arg = cmd[nowAt]
-						err = this.HandleArgument(flag, arg)
-						if err != nil {
-							return fmt.Sprint(err)
-						}
-					}
-				}
-			}
-		} else { //not a flag
-			break
-		}
+	var handler optionHandler = this
+	if cmd[0] == CLIDocker {
+		handler = &versionChecker{this, this.version, RunFlagVersions, &this.Warnings}
+	}
+	nowAt, reason := parseOptions(cmd, 2, runFlagsOf(cmd[0]), handler)
+	if !reason.IsEmpty() {
+		return reason
+	}
+	if this.IsCreate && this.IsDetach {
+		return msg("run.create_detach")
 	}
 	//begain to read images name
 	if nowAt >= len(cmd) {
-		return "Can't find images name from given command!"
+		return msg("run.no_image")
 	}
 	tImagesName := cmd[nowAt]
 	if isImagesName(tImagesName) {
-		if strings.Index(tImagesName, ":") < 0 {
-			tImagesName += ":latest"
-		}
-		this.Images = tImagesName
+		this.Images = withDefaultTag(tImagesName)
+		this.IsTagged = hasImageTag(tImagesName)
 	} else {
-		return fmt.Sprintf("Images name %s not legal!", tImagesName)
+		return msg("run.bad_image", tImagesName)
+	}
+	if reason = this.validate(); !reason.IsEmpty() {
+		return reason
 	}
 	nowAt++
 	//begain to read Command and Arguments
 	if nowAt >= len(cmd) { //no command
-		return ""
+		return Message{}
 	}
 	this.Command = cmd[nowAt]
 	nowAt++
 	if nowAt >= len(cmd) { //no argument
-		return ""
+		return Message{}
 	}
 	this.Arg = cmd[nowAt:]
-	return ""
+	return Message{}
 }
 
 //Setting up the property of a container according to the flag and argument
@@ -220,68 +190,169 @@ func (this *MockContainer) HandleArgument(flag, arg string) error {
 	case "p", "publish":
 		arg = trimStr(arg)
 		if !isPortArg(arg) {
-			return fmt.Errorf("invalid publish opts format (should be port1:port2 but got '%s').", arg)
+			return msg("arg.publish", arg)
 		}
 		ports := strings.Split(arg, ":")
 		_, have := this.Port[ports[0]]
 		if have {
-			return fmt.Errorf("Port is already allocated: %s", ports[0])
+			return msg("arg.port_allocated", ports[0])
 		}
 		this.Port[ports[0]] = ports[1]
 	case "c", "cpu-shares":
 		share, err := strconv.Atoi(arg)
 		if err != nil {
-			return fmt.Errorf("%s need a number, but got: %s", flag, arg)
+			return msg("arg.number", flag, arg)
 		}
 		if share < 2 || share > 262144 {
-			return fmt.Errorf("The allowed cpu-shares is from 2 to 262144")
+			return msg("arg.cpu_shares")
 		}
 		this.CpuShare = share
 	case "v", "volume":
 		arg = trimStr(arg)
 		if !isDirPath(arg) {
-			return fmt.Errorf("Invalid volume argument: %s", arg)
+			return msg("arg.volume", arg)
 		}
 		paths := strings.Split(arg, ":")
 		localPath := paths[0]
 		conPart := paths[1]
+		if len(paths) == 3 { //have options, such as data:/data:ro
+			if err := checkVolumeOptions(this.cli(), paths[2]); err != nil {
+				return err
+			}
+		}
 		if strings.Index(localPath, "$(pwd)") >= 0 {
 			localPath = strings.Replace(localPath, "$(pwd)", "$PWD", 1)
 		}
+		if strings.Index(localPath, "${PWD}") >= 0 {
+			localPath = strings.Replace(localPath, "${PWD}", "$PWD", 1)
+		}
 		localPath = strings.TrimRight(localPath, "\\/")
+		if localPath == "" { //the root of host such as -v /:/host
+			localPath = "/"
+		}
 		conPart = strings.TrimRight(conPart, "\\/")
 		for _, v := range this.Volume {
 			if v == conPart {
-				return fmt.Errorf("Duplicate mount point: %s", conPart)
+				return msg("arg.mount_point", conPart)
 			}
 		}
 		this.Volume[localPath] = conPart
+		if len(paths) == 3 {
+			if this.VolumeOption == nil {
+				this.VolumeOption = make(map[string]string)
+			}
+			this.VolumeOption[localPath] = paths[2]
+		}
 
 	case "name":
 		arg = trimStr(arg)
 		if !isContainerName(arg) {
-			return fmt.Errorf("Invalid container name (%s), only [a-zA-Z0-9][a-zA-Z0-9_.-] are allowed.", arg)
+			return msg("arg.name", arg)
 		}
 		this.ContainerName = arg
 	case "network":
 		this.NetWork = arg
+	case "pod":
+		this.Pod = trimStr(arg)
+	case "userns":
+		arg = trimStr(arg)
+		if !isUserNSMode(this.cli(), arg) {
+			return msg("arg.userns", this.cli(), arg)
+		}
+		this.UserNS = arg
+	case "platform":
+		arg = trimStr(arg)
+		if !isPlatform(arg) || strings.Contains(arg, ",") {
+			return msg("arg.platform", arg)
+		}
+		this.Platform = arg
+	case "pull":
+		arg = trimStr(arg)
+		if !findInArray([]string{"always", "missing", "never"}, arg) {
+			return msg("arg.pull", arg)
+		}
+		this.Pull = arg
+	case "gpus":
+		arg = trimStr(arg)
+		if arg == "" {
+			return msg("arg.gpus", arg)
+		}
+		this.Gpus = arg
+	case "mount":
+		mount, err := parseMount(trimStr(arg))
+		if err != nil {
+			return msg("arg.mount", arg, err)
+		}
+		if (mount["type"] == "bind" || mount["type"] == "volume") && mount["source"] != "" { //the same as -v source:target
+			volume := mount["source"] + ":" + mount["target"]
+			if mount["readonly"] == "true" || mount["readonly"] == "1" {
+				volume += ":ro"
+			}
+			return this.HandleArgument("v", volume)
+		}
+		this.Mount = append(this.Mount, formatMount(mount))
+	case "kernel-memory":
+		arg = trimStr(arg)
+		if !isMemory(arg) {
+			return msg("arg.memory", arg)
+		}
+		this.KernelMemory = arg
+	case "pid":
+		arg = trimStr(arg)
+		if arg != "host" && !strings.HasPrefix(arg, "container:") {
+			return msg("arg.pid")
+		}
+		this.Pid = arg
+	case "cap-add", "cap-drop":
+		capability := strings.TrimPrefix(strings.ToUpper(trimStr(arg)), "CAP_")
+		if capability == "" {
+			return msg("arg.capability", arg)
+		}
+		if flag == "cap-add" {
+			this.CapAdd = append(this.CapAdd, capability)
+		} else {
+			this.CapDrop = append(this.CapDrop, capability)
+		}
+	case "device":
+		arg = trimStr(arg)
+		if !strings.HasPrefix(arg, "/") {
+			return msg("arg.device", arg)
+		}
+		this.Device = append(this.Device, arg)
+	case "restart":
+		arg = trimStr(arg)
+		if !isRestartPolicy(arg) {
+			return msg("arg.restart", arg)
+		}
+		this.Restart = arg
 	case "u", "user":
 		this.User = arg
 	case "w", "workdir":
 		arg = trimStr(arg)
 		if !isWorkDir(arg) {
-			return fmt.Errorf("Invali workdir: %s", arg)
+			return msg("arg.workdir", arg)
 		}
 		this.WorkDir = arg
 	case "h", "hostname":
 		this.HostName = strings.Trim(arg, "\"")
 	case "e", "env":
 		arg = trimStr(arg)
-		this.Env[flag] = arg
+		if arg == "" || strings.HasPrefix(arg, "=") {
+			return msg("arg.env", arg)
+		}
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) == 1 { //such as -e HOME, the value is taken from the host
+			delete(this.Env, arg)
+			if !findInArray(this.EnvFromHost, arg) {
+				this.EnvFromHost = append(this.EnvFromHost, arg)
+			}
+			return nil
+		}
+		this.Env[kv[0]] = kv[1]
 	case "a", "attach":
 		arg = trimStr(arg)
 		if !isAttach(arg) {
-			return fmt.Errorf("Invalid argument '%s' for -a, --attach", arg)
+			return msg("arg.attach", arg)
 		}
 		this.Attach = append(this.Attach, arg)
 	case "l", "label":
@@ -290,20 +361,20 @@ func (this *MockContainer) HandleArgument(flag, arg string) error {
 		this.Link = append(this.Link, arg)
 	case "m", "memory":
 		if !isMemory(arg) {
-			return fmt.Errorf("Invalid memory argument: %s", arg)
+			return msg("arg.memory", arg)
 		}
 		tindex := strings.IndexAny(arg, "bBkKmMgG")
 		if tindex < 0 {
-			return fmt.Errorf("Invalid memory argument: %s", arg)
+			return msg("arg.memory", arg)
 		}
 		numStr := arg[:tindex]
 		MetaStr := arg[tindex:]
 		tnum, err := strconv.Atoi(numStr)
 		if err != nil {
-			return fmt.Errorf("Invalid memory argument: %s", arg)
+			return msg("arg.memory", arg)
 		}
 		if len(MetaStr) == 0 {
-			return fmt.Errorf("Invalid memory argument: %s", arg)
+			return msg("arg.memory", arg)
 		}
 		MetaStr = strings.ToLower(MetaStr)
 		switch MetaStr[0] {
@@ -316,10 +387,10 @@ func (this *MockContainer) HandleArgument(flag, arg string) error {
 		case 'g':
 			this.Memory = tnum << 10
 		default:
-			return fmt.Errorf("Invalid memory argument: %s", arg)
+			return msg("arg.memory", arg)
 		}
 	default:
-		return fmt.Errorf("Invalid flag: --%s", flag)
+		return msg("flag.invalid", flag)
 	}
 	return nil
 }
@@ -330,6 +401,8 @@ func (this *MockContainer) HandleFlag(flag string) error {
 	switch flag {
 	case "P", "publish-all":
 		this.IsPublishAll = true
+	case "privileged":
+		this.IsPrivileged = true
 	case "t", "tty":
 		this.IsTTY = true
 	case "i", "interactive":
@@ -339,128 +412,157 @@ func (this *MockContainer) HandleFlag(flag string) error {
 	case "d", "detach":
 		this.IsDetach = true
 	default:
-		return fmt.Errorf("unknown shorthand flag: '%s'", flag)
+		return msg("flag.invalid_short", flag)
 	}
 	return nil
 }
 
 //printf the property that have been changed of a conatiner
 func (this *MockContainer) Printf() {
-	psic := func(tag, str string) {
-		if str != "" {
-			fmt.Printf("%s  :  %s \n", tag, str)
-		}
-	}
-	pssic := func(tag string, strs []string) {
-		if len(strs) > 0 {
-			fmt.Printf("%s  :  %v \n", tag, strs)
-		}
-	}
-	pbic := func(tag string, b bool) {
-		if b {
-			fmt.Printf("%s  :  %v \n", tag, b)
-		}
-	}
-	pmic := func(tag string, m map[string]string) {
-		if len(m) > 0 {
-			fmt.Printf("%s  :  %v \n", tag, m)
-		}
-	}
-	psic("Images", this.Images)
-	psic("Command", this.Command)
-	psic("HostName", this.HostName)
-	psic("ContainerName", this.ContainerName)
-	psic("User", this.User)
-	psic("WorkDir", this.WorkDir)
-	psic("NetWork", this.NetWork)
-	pbic("IsRemove", this.IsRemove)
-	pbic("IsDetach", this.IsDetach)
-	pbic("IsTTY", this.IsTTY)
-	pbic("IsInteractive", this.IsInteractive)
-	pbic("IsPublishAll", this.IsPublishAll)
-	pssic("Arg", this.Arg)
-	pssic("Attach", this.Attach)
-	pssic("Link", this.Link)
-	pssic("Label", this.Label)
-	pmic("Port", this.Port)
-	pmic("Volume", this.Volume)
-	pmic("Env", this.Env)
-	if this.Memory != 0 {
-		fmt.Println("Memory  :  ", this.Memory)
-	}
+	this.Render(os.Stdout, FormatText)
 }
 
 //judge if the property of a container is right by compared to the answer
 //return a string to describe the mistake or a null string if it command is accepted
 //note that here we have some config do not check: Env[], Label[], Attach[], Link[]
 func Judge(test, ans *MockContainer) string {
+	return judge(test, ans).String()
+}
+
+//judge the property of a container, return the mistake or a empty message if it command is accepted
+func judge(test, ans *MockContainer) Message {
 	if test == nil {
-		return "Given pointer of test is null"
+		return msg("judge.null_test")
 	}
 	if ans == nil {
-		return "Given pointer of ans is null!"
+		return msg("judge.null_ans")
 	}
 	if ans.IsTTY && !test.IsTTY {
-		return "Not found -t or --tty."
+		return msg("judge.tty")
 	}
 	if ans.IsDetach && !test.IsDetach {
-		return "Not found -d or --detach"
+		return msg("judge.detach")
 	}
 	if ans.IsRemove && !test.IsRemove {
-		return "Not found --rm"
+		return msg("judge.remove")
 	}
 	if ans.IsInteractive && !test.IsInteractive {
-		return "not found -i or --interactive"
+		return msg("judge.interactive")
 	}
 	if ans.IsPublishAll && !test.IsPublishAll {
-		return "not found -P or --publish-all"
+		return msg("judge.publish_all")
+	}
+	if ans.IsCreate != test.IsCreate {
+		if ans.IsCreate {
+			return msg("judge.expect_create")
+		}
+		return msg("judge.expect_run")
 	}
 	if ans.WorkDir != "" && test.WorkDir != ans.WorkDir {
-		return fmt.Sprintf("WorkDir not right, expect '%s' but got '%s'.", ans.WorkDir, test.WorkDir)
+		return msg("judge.workdir", ans.WorkDir, test.WorkDir)
 	}
 	if ans.ContainerName != "" && test.ContainerName != ans.ContainerName {
-		return fmt.Sprintf("ContainerName not right, expect '%s' but got '%s'.", ans.ContainerName, test.ContainerName)
+		return msg("judge.name", ans.ContainerName, test.ContainerName)
 	}
 	if ans.User != "" && test.User != ans.User {
-		return fmt.Sprintf("User not right, expect '%s' but got '%s'.", ans.User, test.User)
+		return msg("judge.user", ans.User, test.User)
+	}
+	if ans.Restart != "" && test.Restart != ans.Restart {
+		return msg("judge.restart", ans.Restart, test.Restart)
 	}
 	if ans.HostName != "" && test.HostName != ans.HostName {
-		return fmt.Sprintf("HostName not right, expect '%s' but got '%s'.", ans.HostName, test.HostName)
+		return msg("judge.hostname", ans.HostName, test.HostName)
 	}
 	if ans.CpuShare != test.CpuShare {
-		return fmt.Sprintf("CpuShare not right, expect %d but got %d", ans.CpuShare, test.CpuShare)
+		return msg("judge.cpu_share", ans.CpuShare, test.CpuShare)
 	}
 	if ans.Memory != test.Memory {
-		return fmt.Sprintf("Memory not right, expect %d m but got %d m", ans.Memory, test.Memory)
+		return msg("judge.memory", ans.Memory, test.Memory)
 	}
 	for k, v := range ans.Port {
 		if test.Port[k] != v {
-			return fmt.Sprintf("Port config not right, expect %s:%s but got %s", k, v, test.Port[k])
+			return msg("judge.port", k, v, test.Port[k])
 		}
 	}
 	for k, v := range ans.Volume {
 		if test.Volume[k] != v {
-			return fmt.Sprintf("Volume config not right, expect '%s':'%s' but got '%s'", k, v, test.Volume[k])
+			return msg("judge.volume", k, v, test.Volume[k])
 		}
 	}
+	for k, opts := range ans.VolumeOption {
+		for _, opt := range strings.Split(opts, ",") {
+			if !findInArray(strings.Split(test.VolumeOption[k], ","), opt) {
+				return msg("judge.volume_option", opts, k, test.VolumeOption[k])
+			}
+		}
+	}
+	for k, opts := range test.VolumeOption {
+		if findInArray(strings.Split(opts, ","), "ro") && !findInArray(strings.Split(ans.VolumeOption[k], ","), "ro") {
+			return msg("judge.volume_readonly", k)
+		}
+	}
+	if ans.Platform != "" && test.Platform != ans.Platform {
+		return msg("judge.platform", ans.Platform, test.Platform)
+	}
+	if ans.Pull != "" && test.Pull != ans.Pull {
+		return msg("judge.pull", ans.Pull, test.Pull)
+	}
+	if ans.Gpus != "" && test.Gpus != ans.Gpus {
+		return msg("judge.gpus", ans.Gpus, test.Gpus)
+	}
+	for _, m := range ans.Mount {
+		if !findInArray(test.Mount, m) {
+			return msg("judge.mount", m)
+		}
+	}
+	if ans.IsPrivileged != test.IsPrivileged {
+		return msg("judge.privileged", ans.IsPrivileged, test.IsPrivileged)
+	}
+	if ans.Pid != "" && test.Pid != ans.Pid {
+		return msg("judge.pid", ans.Pid, test.Pid)
+	}
+	for _, c := range ans.CapAdd {
+		if !findInArray(test.CapAdd, c) {
+			return msg("judge.cap_add", c)
+		}
+	}
+	for _, c := range ans.CapDrop {
+		if !findInArray(test.CapDrop, c) {
+			return msg("judge.cap_drop", c)
+		}
+	}
+	for _, d := range ans.Device {
+		if !findInArray(test.Device, d) {
+			return msg("judge.device", d)
+		}
+	}
+	if ans.Pod != "" && test.Pod != ans.Pod {
+		return msg("judge.pod", ans.Pod, test.Pod)
+	}
+	if ans.UserNS != "" && test.UserNS != ans.UserNS {
+		return msg("judge.userns", ans.UserNS, test.UserNS)
+	}
 	if ans.Images != "" && test.Images != ans.Images {
-		return fmt.Sprintf("Images not right, expect '%s' but got '%s'.", ans.Images, test.Images)
+		return msg("judge.images", ans.Images, test.Images)
 	}
 	if ans.Command != "" && test.Command != ans.Command {
-		return fmt.Sprintf("Command not right, expect '%s' but got '%s'.", ans.Command, test.Command)
+		return msg("judge.command", ans.Command, test.Command)
 	}
 	if ans.Command == "" && test.Command != "" {
-		return fmt.Sprintf("Unexpect command: %s", test.Command)
+		return msg("judge.unexpect_command", test.Command)
 	}
 	if len(ans.Arg) != len(test.Arg) {
-		return fmt.Sprintf("Arguments number not right, expect %d but got %d", len(ans.Arg), len(test.Arg))
+		return msg("judge.arg_number", len(ans.Arg), len(test.Arg))
 	}
 	for i := 0; i < len(ans.Arg); i++ { //it operation must place after compare length
 		if ans.Arg[i] != test.Arg[i] {
-			return fmt.Sprintf("Arguments not right, expect '%s' but got '%s'.", ans.Arg[i], test.Arg[i])
+			return msg("judge.arg", ans.Arg[i], test.Arg[i])
 		}
 	}
-	return ""
+	if res := judgeClient(&test.Client, &ans.Client); !res.IsEmpty() {
+		return res
+	}
+	return Message{}
 }
 
 //===================================================================
@@ -470,6 +572,9 @@ func splitCommand(cmd string) []string {
 	cmd = strings.TrimSpace(cmd)
 	cmd = strings.TrimPrefix(cmd, "sudo")
 	cmd = strings.TrimSpace(cmd)
+	if words, err := splitWords(cmd, true); err == nil && len(words) > 0 { //the blanks in quotes are part of the argument
+		return words
+	}
 	newLineReg, _ := regexp.Compile(` \\\n`)
 	cmd = newLineReg.ReplaceAllLiteralString(cmd, " ")
 	blankReg, _ := regexp.Compile(`[ ]+`)
@@ -477,11 +582,24 @@ func splitCommand(cmd string) []string {
 	return cmdArray
 }
 
-//check if the name of images is legal
-//Rule: repository name must be lowercase letter or number and '_', tag of images can be number or letter and '_','.'
+//check if the name of images is legal, it is the same rule as NormalizeImageRef()
+//Rule: [registry/]repository[:tag][@digest], repository name must be lowercase letter or number and '._-'
 func isImagesName(name string) bool {
-	legalReg, _ := regexp.Compile(`^[a-z_0-9\/]+[:]?[\w]+$`)
-	return legalReg.MatchString(name)
+	_, err := NormalizeImageRef(name)
+	return err == nil
+}
+
+//add the default tag 'latest' to the name of images if it have no tag
+func withDefaultTag(name string) string {
+	if !hasImageTag(name) {
+		return name + ":latest"
+	}
+	return name
+}
+
+//check if the name of images have a tag or digest, the port of registry such as localhost:5000/app is not a tag
+func hasImageTag(name string) bool {
+	return strings.Contains(name[strings.LastIndex(name, "/")+1:], ":")
 }
 
 //judge if a port argument is legal, such as 8080:3434 is right
@@ -501,7 +619,7 @@ func isAttach(arg string) bool {
 
 //judge if a path argument can be userd by flag -v or --volume
 func isDirPath(path string) bool {
-	legalReg, _ := regexp.Compile(`^[^: ]+:[^: ]+$`)
+	legalReg, _ := regexp.Compile(`^[^: ]+:[^: ]+(:[^: ]+)?$`)
 	return legalReg.MatchString(path)
 }
 
@@ -517,6 +635,12 @@ func isWorkDir(dir string) bool {
 	return legalReg.MatchString(dir)
 }
 
+//check if the argument can be used by flag --restart, such as always or on-failure:3
+func isRestartPolicy(arg string) bool {
+	reg, _ := regexp.Compile(`^(no|always|unless-stopped|on-failure(:\d+)?)$`)
+	return reg.MatchString(arg)
+}
+
 //check if the argument can be used by flag -m or --menory
 func isMemory(arg string) bool {
 	reg, _ := regexp.Compile(`^\d{1,30}[bBkKmMgG]{1}$`)
@@ -0,0 +1,310 @@
+package DockerRun
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//the state of a container in MockEngine
+const (
+	StateCreated = "created"
+	StateRunning = "running"
+	StateExited  = "exited"
+)
+
+//the network that exist in a new docker daemon
+var defaultNetworks = []string{"bridge", "host", "none"}
+
+//EngineContainer is a container that have been created in MockEngine
+type EngineContainer struct {
+	ID       string
+	Name     string
+	State    string
+	Config   MockContainer
+	Rootfs   *VolumeFS //the files written outside the mount points, it is lost when the container is removed
+	Logs     string
+	ExitCode int
+	Networks []string //the networks that the container connected to
+}
+
+//MockEngine simulate the state of a docker daemon, so that a series of commands can be applied and judged together
+type MockEngine struct {
+	Images        map[string]bool //the normalized reference of images, such as docker.io/library/alpine:latest
+	Networks      map[string]bool
+	Volumes       map[string]bool
+	Containers    []*EngineContainer //in the order of creation
+	ports         map[string]*EngineContainer
+	filesystems   map[string]*VolumeFS
+	archives      map[string][]string     //the images saved by docker save
+	networkConfig map[string]*MockNetwork //the command that created a user-defined network
+	volumeConfig  map[string]*MockVolume  //the command that created a volume by docker volume create
+	created       int
+}
+
+//create a MockEngine that only have the default networks
+func NewMockEngine() *MockEngine {
+	engine := &MockEngine{
+		Images:   make(map[string]bool),
+		Networks: make(map[string]bool),
+		Volumes:  make(map[string]bool),
+		ports:    make(map[string]*EngineContainer),
+	}
+	for _, n := range defaultNetworks {
+		engine.Networks[n] = true
+	}
+	return engine
+}
+
+//find a container by name or the prefix of id, return nil if not found
+func (this *MockEngine) Container(nameOrID string) *EngineContainer {
+	nameOrID = strings.TrimPrefix(nameOrID, "/")
+	for _, c := range this.Containers {
+		if c.Name == nameOrID {
+			return c
+		}
+	}
+	if len(nameOrID) < 3 {
+		return nil
+	}
+	for _, c := range this.Containers {
+		if strings.HasPrefix(c.ID, nameOrID) {
+			return c
+		}
+	}
+	return nil
+}
+
+//run a container like 'docker run', the image is pulled and the named volume is created if not exist,
+//return the same error as docker daemon if the container name, host port, network or linked container is not available
+func (this *MockEngine) Run(ctr *MockContainer) (*EngineContainer, error) {
+	if ctr.ContainerName != "" {
+		if old := this.Container(ctr.ContainerName); old != nil && old.Name == ctr.ContainerName {
+			return nil, fmt.Errorf("Conflict. The container name \"/%s\" is already in use by container \"%s\". You have to remove (or rename) that container to be able to reuse that name.", old.Name, old.ID)
+		}
+	}
+	if ctr.NetWork != "" && !this.Networks[ctr.NetWork] {
+		return nil, fmt.Errorf("network %s not found", ctr.NetWork)
+	}
+	for _, l := range ctr.Link {
+		target := this.Container(strings.Split(l, ":")[0])
+		if target == nil {
+			return nil, fmt.Errorf("could not get container for %s", strings.Split(l, ":")[0])
+		}
+		if target.State != StateRunning {
+			return nil, fmt.Errorf("Cannot link to a non running container: /%s AS /%s/%s", target.Name, ctr.ContainerName, linkAlias(l))
+		}
+	}
+	c := this.create(ctr)
+	if ctr.IsCreate { //docker create only create the container
+		return c, nil
+	}
+	if err := this.start(c); err != nil { //like docker the container is left in created state and its name is still in use
+		return nil, err
+	}
+	if isSimulated(ctr.Command, ctr.Arg) { //a simulated command exit after it finished even if the container is detached
+		output, err := this.execute(c, ctr.WorkDir, ctr.Command, ctr.Arg)
+		c.Logs += output
+		if err != nil {
+			c.Logs += err.Error() + "\n"
+			c.ExitCode = 1
+		}
+		this.exit(c)
+	}
+	if !ctr.IsDetach { //a foreground container exit when its command finished
+		this.exit(c)
+	}
+	return c, nil
+}
+
+//parse and apply a series of docker commands, return the error of the first failed command
+func (this *MockEngine) RunCommands(cmds []string) error {
+	for i, cmd := range cmds {
+		if _, err := this.Apply(cmd); err != nil {
+			return msg("engine.command", i+1, toMessage(err))
+		}
+	}
+	return nil
+}
+
+//add a container in created state
+func (this *MockEngine) create(ctr *MockContainer) *EngineContainer {
+	this.created++
+	if ref, err := NormalizeImageRef(ctr.Images); err == nil {
+		this.Images[ref] = true
+	}
+	for source := range ctr.Volume {
+		if isNamedVolume(source) {
+			this.Volumes[source] = true
+		}
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d %s %s", this.created, ctr.ContainerName, ctr.Images)))
+	c := &EngineContainer{
+		ID:     fmt.Sprintf("%x", sum),
+		Name:   ctr.ContainerName,
+		State:  StateCreated,
+		Config: *ctr,
+	}
+	for i := this.created; c.Name == ""; i++ { //the generated name should not be used by other container such as --name container_2
+		c.Name = fmt.Sprintf("container_%d", i)
+		if old := this.Container(c.Name); old != nil && old.Name == c.Name {
+			c.Name = ""
+		}
+	}
+	c.Networks = []string{"bridge"}
+	if ctr.NetWork != "" {
+		c.Networks = []string{ctr.NetWork}
+	}
+	this.Containers = append(this.Containers, c)
+	return c
+}
+
+//start a container and allocate its host port
+func (this *MockEngine) start(c *EngineContainer) error {
+	if c.State == StateRunning {
+		return nil
+	}
+	for _, hostPort := range sortedKeys(c.Config.Port) {
+		if owner, have := this.ports[hostPort]; have && owner != c {
+			for p, o := range this.ports { //release the port that allocated before the conflict
+				if o == c {
+					delete(this.ports, p)
+				}
+			}
+			return fmt.Errorf("driver failed programming external connectivity on endpoint %s (%s): Bind for 0.0.0.0:%s failed: port is already allocated", c.Name, c.ID[:12], hostPort)
+		}
+		this.ports[hostPort] = c
+	}
+	c.State = StateRunning
+	return nil
+}
+
+//stop a container and release its host port
+func (this *MockEngine) stop(c *EngineContainer) {
+	for p, o := range this.ports {
+		if o == c {
+			delete(this.ports, p)
+		}
+	}
+	if c.State == StateRunning {
+		c.State = StateExited
+	}
+}
+
+//stop a container because its command finished or it is stopped by docker stop or kill,
+//the container with --rm is removed at the same time
+func (this *MockEngine) exit(c *EngineContainer) {
+	this.stop(c)
+	if c.Config.IsRemove {
+		this.remove(c)
+	}
+}
+
+//remove a container from the engine
+func (this *MockEngine) remove(c *EngineContainer) {
+	this.stop(c)
+	for i, o := range this.Containers {
+		if o == c {
+			this.Containers = append(this.Containers[:i], this.Containers[i+1:]...)
+			return
+		}
+	}
+}
+
+//return the host ports that have been allocated by running containers
+func (this *MockEngine) PublishedPorts() map[string]string {
+	result := make(map[string]string)
+	for p, c := range this.ports {
+		result[p] = c.Name
+	}
+	return result
+}
+
+//judge if the final state of the engine is right by compared to the answer engine,
+//a named container must exist with the same state and the same config, a container without name is matched by any container like it,
+//the networks and volumes in the answer must also exist
+//return a string to describe the mistake or a null string if it is accepted
+func JudgeEngine(test, ans *MockEngine) string {
+	return judgeEngine(test, ans).String()
+}
+
+//judge the final state of the engine, return the mistake or a empty message if it is accepted
+func judgeEngine(test, ans *MockEngine) Message {
+	if test == nil || ans == nil {
+		return msg("judge.engine_null")
+	}
+	for _, a := range ans.Containers {
+		if a.Config.ContainerName != "" {
+			t := test.Container(a.Name)
+			if t == nil || t.Name != a.Name {
+				return msg("engine.container_missing", a.Name)
+			}
+			if t.State != a.State {
+				return msg("engine.state", a.Name, a.State, t.State)
+			}
+			if reason := judgeEngineContainer(t, a); !reason.IsEmpty() {
+				return msg("engine.container", a.Name, reason)
+			}
+			continue
+		}
+		found := false
+		for _, t := range test.Containers {
+			if t.State == a.State && judgeEngineContainer(t, a).IsEmpty() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return msg("engine.like", a.State, a.Config.Images)
+		}
+	}
+	for _, n := range sortedSet(ans.Networks) {
+		if !test.Networks[n] {
+			return msg("engine.network_missing", n)
+		}
+	}
+	for _, v := range sortedSet(ans.Volumes) {
+		if !test.Volumes[v] {
+			return msg("engine.volume_missing", v)
+		}
+	}
+	return Message{}
+}
+
+//judge the config of a container in engine by compared to the answer, how it is started (docker create or -d)
+//is judged by the state and the networks are judged by the networks that it connected to at last
+func judgeEngineContainer(t, a *EngineContainer) Message {
+	config := t.Config
+	config.IsCreate, config.IsDetach, config.NetWork = a.Config.IsCreate, a.Config.IsDetach, a.Config.NetWork
+	if reason := judge(&config, &a.Config); !reason.IsEmpty() {
+		return reason
+	}
+	for _, n := range a.Networks {
+		if !findInArray(t.Networks, n) {
+			return msg("engine.not_connected", n)
+		}
+	}
+	for _, n := range t.Networks {
+		if !findInArray(a.Networks, n) {
+			return msg("judge.unexpect_network", n)
+		}
+	}
+	return Message{}
+}
+
+//return the alias of a link argument, such as db in database:db
+func linkAlias(link string) string {
+	parts := strings.Split(link, ":")
+	return parts[len(parts)-1]
+}
+
+//return the keys of a set in order
+func sortedSet(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
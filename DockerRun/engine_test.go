@@ -0,0 +1,72 @@
+package DockerRun
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMockEngine(t *testing.T) {
+	engine := NewMockEngine()
+	err := engine.RunCommands([]string{
+		`docker run -v username_vol:/data --name server1 username/1000010024_server:latest`,
+		`docker run -t --rm -w /data -v username_vol:/data username/1000010024_server:latest touch hello.txt`,
+		`docker run -d -p 8081:8080 --name server2 username/1000010024_server:latest`,
+	})
+	if err != nil {
+		t.Fatalf("run commands fail: %v", err)
+	}
+	if len(engine.Containers) != 2 || engine.Container("server1").State != StateExited || engine.Container("server2").State != StateRunning {
+		t.Fatalf("unexpect containers: %v", engine.Containers)
+	}
+	if !engine.Volumes["username_vol"] || !engine.HasImage("username/1000010024_server") {
+		t.Fatalf("volume and images should be created")
+	}
+	conflicts := map[string]string{
+		`docker run --name server1 alpine`:                      `The container name "/server1" is already in use`,
+		`docker run -d -p 8081:80 alpine`:                       `port is already allocated`,
+		`docker run --network netname alpine`:                   `network netname not found`,
+		`docker run --link server1:db alpine`:                   `Cannot link to a non running container`,
+		`docker run -d -p 9090:80 -p 8081:81 --name web alpine`: `port is already allocated`,
+	}
+	for cmd, expect := range conflicts {
+		if err := engine.RunCommands([]string{cmd}); err == nil || !strings.Contains(err.Error(), expect) {
+			t.Fatalf("command '%s' expect error '%s' but got: %v", cmd, expect, err)
+		}
+	}
+	if len(engine.PublishedPorts()) != 1 || engine.Container("web") == nil || engine.Container("web").State != StateCreated {
+		t.Fatalf("failed container should release its port and stay created: %v", engine.PublishedPorts())
+	}
+	if err := engine.RunCommands([]string{`docker run -d -p 9091:80 --name web alpine`}); err == nil || !strings.Contains(err.Error(), `The container name "/web" is already in use`) {
+		t.Fatalf("the name of a created container should be in use: %v", err)
+	}
+	if err := engine.RunCommands([]string{`docker rm web`}); err != nil {
+		t.Fatalf("remove created container fail: %v", err)
+	}
+	ans := NewMockEngine()
+	ans.RunCommands([]string{`docker run -d --name server2 -p 8081:8080 username/1000010024_server`})
+	if res := JudgeEngine(engine, ans); res != "" {
+		t.Fatalf("engine should pass: %s", res)
+	}
+	if JudgeEngine(ans, engine) == "" {
+		t.Fatalf("engine without server1 should not pass")
+	}
+}
+
+func TestEngineGeneratedName(t *testing.T) {
+	engine := NewMockEngine()
+	err := engine.RunCommands([]string{
+		`docker run -d --name container_2 nginx`,
+		`docker run -d nginx`,
+		`docker run -d nginx`,
+	})
+	if err != nil {
+		t.Fatalf("run commands fail: %v", err)
+	}
+	names := []string{}
+	for _, c := range engine.Containers {
+		names = append(names, c.Name)
+	}
+	if strings.Join(names, ",") != "container_2,container_3,container_4" {
+		t.Fatalf("generated name should not be used by other container: %v", names)
+	}
+}
@@ -0,0 +1,252 @@
+package DockerRun
+
+import (
+	"encoding/json"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+//the following is a subset of the body of Docker Engine API 'POST /containers/create',
+//only the field that can be set by MockContainer is declared, so that we don't need to depend on the docker SDK
+
+//EngineCreateBody is the Config part and the HostConfig, NetworkingConfig of a create request
+type EngineCreateBody struct {
+	Hostname         string                 `json:",omitempty"`
+	User             string                 `json:",omitempty"`
+	AttachStdin      bool                   `json:"AttachStdin"`
+	AttachStdout     bool                   `json:"AttachStdout"`
+	AttachStderr     bool                   `json:"AttachStderr"`
+	ExposedPorts     map[string]struct{}    `json:",omitempty"`
+	Tty              bool                   `json:"Tty"`
+	OpenStdin        bool                   `json:"OpenStdin"`
+	StdinOnce        bool                   `json:"StdinOnce"`
+	Env              []string               `json:",omitempty"`
+	Cmd              []string               `json:",omitempty"`
+	Image            string                 `json:"Image"`
+	WorkingDir       string                 `json:",omitempty"`
+	Labels           map[string]string      `json:",omitempty"`
+	HostConfig       EngineHostConfig       `json:"HostConfig"`
+	NetworkingConfig EngineNetworkingConfig `json:"NetworkingConfig"`
+}
+
+//EngineHostConfig is the HostConfig part of a create request
+type EngineHostConfig struct {
+	Binds           []string                       `json:",omitempty"`
+	Mounts          []EngineMount                  `json:",omitempty"`
+	PortBindings    map[string][]EnginePortBinding `json:",omitempty"`
+	PublishAllPorts bool                           `json:"PublishAllPorts"`
+	AutoRemove      bool                           `json:"AutoRemove"`
+	NetworkMode     string                         `json:",omitempty"`
+	RestartPolicy   EngineRestartPolicy            `json:"RestartPolicy"`
+	Links           []string                       `json:",omitempty"`
+	Memory          int64                          `json:",omitempty"`
+	CpuShares       int64                          `json:",omitempty"`
+	Privileged      bool                           `json:"Privileged"`
+	PidMode         string                         `json:",omitempty"`
+	CapAdd          []string                       `json:",omitempty"`
+	CapDrop         []string                       `json:",omitempty"`
+	Devices         []EngineDevice                 `json:",omitempty"`
+}
+
+//EngineDevice is a device of host that added to the container
+type EngineDevice struct {
+	PathOnHost        string `json:"PathOnHost"`
+	PathInContainer   string `json:"PathInContainer"`
+	CgroupPermissions string `json:"CgroupPermissions"`
+}
+
+//EngineMount is a named volume or a mount of --mount such as a tmpfs
+type EngineMount struct {
+	Type         string              `json:"Type"`
+	Source       string              `json:"Source"`
+	Target       string              `json:"Target"`
+	ReadOnly     bool                `json:"ReadOnly,omitempty"`
+	TmpfsOptions *EngineTmpfsOptions `json:",omitempty"`
+}
+
+//EngineTmpfsOptions is the size and mode of a tmpfs mount
+type EngineTmpfsOptions struct {
+	SizeBytes int64 `json:",omitempty"`
+	Mode      int   `json:",omitempty"`
+}
+
+//EnginePortBinding is the host side of a published port
+type EnginePortBinding struct {
+	HostIp   string `json:"HostIp"`
+	HostPort string `json:"HostPort"`
+}
+
+//EngineRestartPolicy is the restart policy of a container, such as {"Name": "on-failure", "MaximumRetryCount": 3}
+type EngineRestartPolicy struct {
+	Name              string `json:"Name"`
+	MaximumRetryCount int    `json:"MaximumRetryCount"`
+}
+
+//EngineNetworkingConfig is the NetworkingConfig part of a create request
+type EngineNetworkingConfig struct {
+	EndpointsConfig map[string]struct{} `json:",omitempty"`
+}
+
+//convert a container into the body of 'POST /containers/create',
+//pwd is used to replace $PWD in the host path of a volume, it is required by the daemon that bind source is an absolute path,
+//the variables such as -e HOME take the value from the environment of this process like docker cli
+func (this *MockContainer) ToEngineCreate(pwd string) EngineCreateBody {
+	body := EngineCreateBody{
+		Hostname:   this.HostName,
+		User:       this.User,
+		Tty:        this.IsTTY,
+		OpenStdin:  this.IsInteractive,
+		StdinOnce:  this.IsInteractive && !this.IsDetach,
+		Image:      this.Images,
+		WorkingDir: this.WorkDir,
+	}
+	if len(this.Attach) > 0 {
+		for _, a := range this.Attach {
+			switch strings.ToLower(a) {
+			case "stdin":
+				body.AttachStdin = true
+			case "stdout":
+				body.AttachStdout = true
+			case "stderr":
+				body.AttachStderr = true
+			}
+		}
+	} else if !this.IsDetach { //the same default as docker cli
+		body.AttachStdin = this.IsInteractive
+		body.AttachStdout = true
+		body.AttachStderr = true
+	}
+	if this.Command != "" {
+		body.Cmd = append([]string{this.Command}, this.Arg...)
+	}
+	for _, k := range sortedKeys(this.Env) {
+		body.Env = append(body.Env, k+"="+this.Env[k])
+	}
+	for _, k := range this.EnvFromHost { //the same as docker cli, a variable that is not set on the host is omitted
+		if v, have := os.LookupEnv(k); have {
+			body.Env = append(body.Env, k+"="+v)
+		}
+	}
+	for _, l := range this.Label {
+		if body.Labels == nil {
+			body.Labels = make(map[string]string)
+		}
+		kv := strings.SplitN(trimStr(l), "=", 2)
+		if len(kv) == 1 {
+			kv = append(kv, "")
+		}
+		body.Labels[kv[0]] = kv[1]
+	}
+	host := &body.HostConfig
+	for _, hostPort := range sortedKeys(this.Port) {
+		conPort := this.Port[hostPort] + "/tcp"
+		if body.ExposedPorts == nil {
+			body.ExposedPorts = make(map[string]struct{})
+			host.PortBindings = make(map[string][]EnginePortBinding)
+		}
+		body.ExposedPorts[conPort] = struct{}{}
+		host.PortBindings[conPort] = append(host.PortBindings[conPort], EnginePortBinding{HostPort: hostPort})
+	}
+	for _, source := range sortedKeys(this.Volume) {
+		target := this.Volume[source]
+		if isNamedVolume(source) {
+			readOnly := findInArray(strings.Split(this.VolumeOption[source], ","), "ro")
+			host.Mounts = append(host.Mounts, EngineMount{Type: "volume", Source: source, Target: target, ReadOnly: readOnly})
+			continue
+		}
+		if opts, have := this.VolumeOption[source]; have {
+			target += ":" + opts
+		}
+		if pwd != "" {
+			source = strings.Replace(source, "$PWD", pwd, 1)
+		}
+		host.Binds = append(host.Binds, path.Clean(source)+":"+target)
+	}
+	for _, m := range this.Mount { //the mounts that can't be written as -v, such as type=tmpfs,target=/tmp
+		mount, err := parseMount(m)
+		if err != nil {
+			continue
+		}
+		readOnly := mount["readonly"] == "true" || mount["readonly"] == "1"
+		engineMount := EngineMount{Type: mount["type"], Source: mount["source"], Target: mount["target"], ReadOnly: readOnly}
+		if mount["tmpfs-size"] != "" || mount["tmpfs-mode"] != "" {
+			engineMount.TmpfsOptions = &EngineTmpfsOptions{SizeBytes: byteSize(mount["tmpfs-size"])}
+			mode, _ := strconv.ParseInt(mount["tmpfs-mode"], 8, 32)
+			engineMount.TmpfsOptions.Mode = int(mode)
+		}
+		host.Mounts = append(host.Mounts, engineMount)
+	}
+	host.PublishAllPorts = this.IsPublishAll
+	host.AutoRemove = this.IsRemove
+	host.NetworkMode = this.NetWork
+	if this.Restart != "" {
+		policy := strings.SplitN(this.Restart, ":", 2)
+		host.RestartPolicy.Name = policy[0]
+		if len(policy) == 2 {
+			host.RestartPolicy.MaximumRetryCount, _ = strconv.Atoi(policy[1])
+		}
+	}
+	host.Links = this.Link
+	host.Memory = int64(this.Memory) << 20
+	host.CpuShares = int64(this.CpuShare)
+	host.Privileged = this.IsPrivileged
+	host.PidMode = this.Pid
+	host.CapAdd = this.CapAdd
+	host.CapDrop = this.CapDrop
+	for _, d := range this.Device { //such as /dev/sda:/dev/xvdc:rwm
+		parts := strings.Split(d, ":")
+		device := EngineDevice{PathOnHost: parts[0], PathInContainer: parts[0], CgroupPermissions: "rwm"}
+		if len(parts) > 1 && strings.HasPrefix(parts[1], "/") {
+			device.PathInContainer = parts[1]
+		}
+		if len(parts) > 1 && !strings.HasPrefix(parts[len(parts)-1], "/") {
+			device.CgroupPermissions = parts[len(parts)-1]
+		}
+		host.Devices = append(host.Devices, device)
+	}
+	if this.NetWork != "" && !findInArray([]string{"bridge", "host", "none", "default"}, this.NetWork) {
+		body.NetworkingConfig.EndpointsConfig = map[string]struct{}{this.NetWork: {}}
+	}
+	return body
+}
+
+//return the url path and the json body that can be post to a docker daemon to create the container
+func (this *MockContainer) EngineCreateRequest(pwd string) (string, []byte, error) {
+	target := "/containers/create"
+	if this.ContainerName != "" {
+		target += "?name=" + url.QueryEscape(this.ContainerName)
+	}
+	body, err := json.Marshal(this.ToEngineCreate(pwd))
+	return target, body, err
+}
+
+//return the bytes of a size such as 64m, the units are k, m and g, it return 0 if the size is not legal
+func byteSize(size string) int64 {
+	size = strings.ToLower(size)
+	shift := uint(0)
+	switch {
+	case strings.HasSuffix(size, "k"):
+		shift = 10
+	case strings.HasSuffix(size, "m"):
+		shift = 20
+	case strings.HasSuffix(size, "g"):
+		shift = 30
+	}
+	if shift != 0 {
+		size = size[:len(size)-1]
+	}
+	n, err := strconv.ParseInt(strings.TrimSuffix(size, "b"), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n << shift
+}
+
+//judge if the host part of a volume argument is a named volume rather than a path, such as username_vol,
+//the rule of volume name is the same as container name
+func isNamedVolume(source string) bool {
+	return isContainerName(source)
+}
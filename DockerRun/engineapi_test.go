@@ -0,0 +1,48 @@
+package DockerRun
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestEngineCreateRequest(t *testing.T) {
+	ctr, _ := NewMockContainer(`docker run -d --name web -m 1g -c 512 -e MODE=prod -e PATH -e DOCKERRUN_NOT_SET -p 8081:8080 -v $PWD/data:/data -v username_vol:/var/lib/data --mount type=tmpfs,dst=/cache,tmpfs-size=64m,tmpfs-mode=1770 --mount type=image,src=alpine,dst=/img --network netname images:latest serve --port 8080`)
+	target, data, err := ctr.EngineCreateRequest("/home/student")
+	if err != nil {
+		t.Fatalf("create request fail: %v", err)
+	}
+	if target != "/containers/create?name=web" {
+		t.Fatalf("unexpect target: %s", target)
+	}
+	var body EngineCreateBody
+	if err := json.Unmarshal(data, &body); err != nil {
+		t.Fatalf("body is not a valid json: %v", err)
+	}
+	host := body.HostConfig
+	switch {
+	case body.Image != "images:latest" || len(body.Cmd) != 3 || body.Cmd[0] != "serve":
+		t.Fatalf("unexpect image or cmd: %s %v", body.Image, body.Cmd)
+	case len(body.Env) != 2 || body.Env[0] != "MODE=prod" || body.Env[1] != "PATH="+os.Getenv("PATH"):
+		t.Fatalf("unexpect env: %v", body.Env)
+	case body.AttachStdout || body.AttachStderr:
+		t.Fatalf("detached container should not attach output")
+	case host.Memory != 1<<30 || host.CpuShares != 512:
+		t.Fatalf("unexpect resource: %d %d", host.Memory, host.CpuShares)
+	case len(host.PortBindings["8080/tcp"]) != 1 || host.PortBindings["8080/tcp"][0].HostPort != "8081":
+		t.Fatalf("unexpect port bindings: %v", host.PortBindings)
+	case len(host.Binds) != 1 || host.Binds[0] != "/home/student/data:/data":
+		t.Fatalf("unexpect binds: %v", host.Binds)
+	case len(host.Mounts) != 3 || host.Mounts[0].Source != "username_vol" || host.Mounts[0].Type != "volume":
+		t.Fatalf("unexpect mounts: %v", host.Mounts)
+	case host.Mounts[1].Type != "tmpfs" || host.Mounts[1].Target != "/cache" || host.Mounts[1].TmpfsOptions == nil || host.Mounts[1].TmpfsOptions.SizeBytes != 64<<20 || host.Mounts[1].TmpfsOptions.Mode != 01770:
+		t.Fatalf("unexpect tmpfs mount: %v", host.Mounts[1])
+	case host.Mounts[2].Type != "image" || host.Mounts[2].Source != "alpine" || host.Mounts[2].Target != "/img":
+		t.Fatalf("unexpect image mount: %v", host.Mounts[2])
+	case host.NetworkMode != "netname":
+		t.Fatalf("unexpect network mode: %s", host.NetworkMode)
+	}
+	if _, have := body.NetworkingConfig.EndpointsConfig["netname"]; !have {
+		t.Fatalf("user defined network should be in EndpointsConfig")
+	}
+}
@@ -0,0 +1,200 @@
+package DockerRun
+
+import (
+	"fmt"
+	"strings"
+)
+
+//ExecFlags is all flags allowled to used in docker exec
+var ExecFlags = FlagSet{
+	Short: []string{"i", "t", "d", "u", "w", "e"},
+	Long:  []string{"interactive", "tty", "detach", "user", "workdir", "env", "privileged"},
+	NoArg: []string{"i", "interactive", "t", "tty", "d", "detach", "privileged"},
+}
+
+//the model to simulate the property of a docker exec command
+type MockExec struct {
+	Container     string
+	Command       string
+	Arg           []string
+	Env           map[string]string
+	User          string
+	WorkDir       string
+	IsDetach      bool
+	IsTTY         bool
+	IsInteractive bool
+	IsPrivileged  bool
+	Client        ClientConfig //the global options before the subcommand
+}
+
+//create an MockExec according to a docker exec command, return error if it command have a worng syntax
+func NewMockExec(dockerCmd string) (model MockExec, err error) {
+	model.Env = make(map[string]string)
+	cmdArray := splitCommand(dockerCmd)
+	if reason := model.basicCheck(cmdArray); !reason.IsEmpty() {
+		return model, reason
+	}
+	return model, nil
+}
+
+//check the basic syntax of a docker exec command,
+//return the fall reason or return a empty string if the command is accpeted
+//synatax: docker exec [OPTIONS] CONTAINER COMMAND [ARG...]
+func (this *MockExec) BasicCheck(cmd []string) string {
+	return this.basicCheck(cmd).String()
+}
+
+//check the basic syntax of a docker exec command, return the fall reason or a empty message if the command is accpeted
+func (this *MockExec) basicCheck(cmd []string) Message {
+	if len(cmd) == 0 {
+		return msg("run.empty")
+	}
+	if len(cmd) < 2 {
+		return msg("run.too_short")
+	}
+	if cmd[0] != "docker" {
+		return msg("run.not_docker")
+	}
+	cmd, reason := stripGlobalOptions(cmd, &this.Client)
+	if !reason.IsEmpty() {
+		return reason
+	}
+	cmd = normalizeCommand(cmd)
+	if cmd[1] != "exec" {
+		return msg("command.not_kind", "exec")
+	}
+	nowAt, reason := parseOptions(cmd, 2, ExecFlags, this)
+	if !reason.IsEmpty() {
+		return reason
+	}
+	if nowAt+1 >= len(cmd) {
+		return msg("command.at_least_two", "exec")
+	}
+	this.Container = trimStr(cmd[nowAt])
+	if !isContainerName(this.Container) {
+		return msg("arg.name", this.Container)
+	}
+	this.Command = cmd[nowAt+1]
+	if nowAt+2 < len(cmd) {
+		this.Arg = cmd[nowAt+2:]
+	}
+	return Message{}
+}
+
+//Setting up the property of a exec according to the flag and argument
+//if the format of arguments not right it will return error
+func (this *MockExec) HandleArgument(flag, arg string) error {
+	arg = trimStr(arg)
+	switch flag {
+	case "u", "user":
+		this.User = arg
+	case "w", "workdir":
+		if !isWorkDir(arg) {
+			return msg("arg.workdir", arg)
+		}
+		this.WorkDir = arg
+	case "e", "env":
+		if arg == "" || strings.HasPrefix(arg, "=") {
+			return msg("arg.env", arg)
+		}
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) == 1 {
+			kv = append(kv, "")
+		}
+		this.Env[kv[0]] = kv[1]
+	default:
+		return msg("flag.invalid", flag)
+	}
+	return nil
+}
+
+//Setting up the property of a exec according to the flag that without argument
+//return error only if the flag is not exist
+func (this *MockExec) HandleFlag(flag string) error {
+	switch flag {
+	case "t", "tty":
+		this.IsTTY = true
+	case "i", "interactive":
+		this.IsInteractive = true
+	case "d", "detach":
+		this.IsDetach = true
+	case "privileged":
+		this.IsPrivileged = true
+	default:
+		return msg("flag.invalid_short", flag)
+	}
+	return nil
+}
+
+//judge if the property of a exec is right by compared to the answer
+//return a string to describe the mistake or a null string if it command is accepted
+//note that here we have some config do not check: Env[]
+func JudgeExec(test, ans *MockExec) string {
+	return judgeExec(test, ans).String()
+}
+
+//judge the property of a docker exec command, return the mistake or a empty message if it command is accepted
+func judgeExec(test, ans *MockExec) Message {
+	if test == nil {
+		return msg("judge.null_test")
+	}
+	if ans == nil {
+		return msg("judge.null_ans")
+	}
+	if test.Container != ans.Container {
+		return msg("judge.container", ans.Container, test.Container)
+	}
+	if ans.IsTTY && !test.IsTTY {
+		return msg("judge.tty")
+	}
+	if ans.IsDetach && !test.IsDetach {
+		return msg("judge.detach")
+	}
+	if ans.IsInteractive && !test.IsInteractive {
+		return msg("judge.interactive")
+	}
+	if ans.IsPrivileged && !test.IsPrivileged {
+		return msg("judge.flag", "--privileged")
+	}
+	if ans.WorkDir != "" && test.WorkDir != ans.WorkDir {
+		return msg("judge.workdir", ans.WorkDir, test.WorkDir)
+	}
+	if ans.User != "" && test.User != ans.User {
+		return msg("judge.user", ans.User, test.User)
+	}
+	if test.Command != ans.Command {
+		return msg("judge.command", ans.Command, test.Command)
+	}
+	if len(ans.Arg) != len(test.Arg) {
+		return msg("judge.arg_number", len(ans.Arg), len(test.Arg))
+	}
+	for i := 0; i < len(ans.Arg); i++ {
+		if ans.Arg[i] != test.Arg[i] {
+			return msg("judge.arg", ans.Arg[i], test.Arg[i])
+		}
+	}
+	if reason := judgeClient(&test.Client, &ans.Client); !reason.IsEmpty() {
+		return reason
+	}
+	return Message{}
+}
+
+//run a command in a container like 'docker exec', the container must exist and be running,
+//the simulated command is executed and its output is returned, other commands are only checked
+func (this *MockEngine) Exec(e *MockExec) (string, error) {
+	c := this.Container(e.Container)
+	if c == nil {
+		return "", fmt.Errorf("No such container: %s", e.Container)
+	}
+	if c.State != StateRunning {
+		return "", fmt.Errorf("Container %s is not running", c.ID)
+	}
+	if !isSimulated(e.Command, e.Arg) {
+		return "", nil
+	}
+	workDir := e.WorkDir
+	if workDir == "" {
+		workDir = c.Config.WorkDir
+	}
+	return this.execute(c, workDir, e.Command, e.Arg)
+}
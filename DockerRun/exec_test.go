@@ -0,0 +1,81 @@
+package DockerRun
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMockExec(t *testing.T) {
+	standard := `docker exec -it server1 sh`
+	challenger := []string{
+		`docker exec -i -t server1 sh`,
+		`sudo docker exec --interactive --tty=true server1 sh`,
+		`docker exec -it -e MODE=dev "server1" sh`,
+	}
+	stdExec, err := NewMockExec(standard)
+	if err != nil {
+		t.Fatalf("Standard command fall: %v", err)
+	}
+	for i, cmd := range challenger {
+		e, err := NewMockExec(cmd)
+		if err != nil {
+			t.Fatalf("Create exec fail at command %d : %v", i, err)
+		}
+		if res := JudgeExec(&e, &stdExec); res != "" {
+			t.Fatalf("Unpass at command %d : %v", i, res)
+		}
+	}
+	for _, cmd := range []string{`docker exec -it server1`, `docker exec -p 80:80 server1 sh`, `docker run -it server1 sh`} {
+		if _, err := NewMockExec(cmd); err == nil {
+			t.Fatalf("worng command %s pass!", cmd)
+		}
+	}
+	e, _ := NewMockExec(`docker exec -t server1 sh`)
+	if JudgeExec(&e, &stdExec) == "" {
+		t.Fatalf("exec without -i should not pass")
+	}
+}
+
+func TestMockEngineExec(t *testing.T) {
+	engine := NewMockEngine()
+	engine.RunCommands([]string{
+		`docker run -d -v username_vol:/data --name server1 username/1000010024_server:latest`,
+		`docker run --name server2 username/1000010024_server:latest`,
+	})
+	expect := map[string]string{
+		`docker exec server0 ls`:                            `No such container: server0`,
+		`docker exec server2 ls`:                            `is not running`,
+		`docker exec -w /data server1 touch hello.txt`:      ``,
+		`docker exec -w /data server1 cat missing.txt`:      `No such file or directory`,
+		`docker exec -it server1 sh -c 'echo hi > /data/a'`: ``,
+		`docker exec -it server1 sh`:                        ``,
+		`docker exec -it server1 bash`:                      ``,
+	}
+	for cmd, msg := range expect {
+		e, err := NewMockExec(cmd)
+		if err != nil {
+			t.Fatalf("Create exec fail at command %s : %v", cmd, err)
+		}
+		_, err = engine.Exec(&e)
+		if (msg == "" && err != nil) || (msg != "" && (err == nil || !strings.Contains(err.Error(), msg))) {
+			t.Fatalf("command '%s' expect error '%s' but got: %v", cmd, msg, err)
+		}
+	}
+	if content, _ := engine.VolumeFS("username_vol").ReadFile("/a"); content != "hi\n" {
+		t.Fatalf("unexpect content of a: %q", content)
+	}
+	if _, have := engine.VolumeFS("username_vol").ReadFile("/hello.txt"); !have {
+		t.Fatalf("hello.txt should be created")
+	}
+	if c, err := engine.Run(mustContainer(t, `docker run -it --name shell alpine sh`)); err != nil || c.ExitCode != 0 || c.Logs != "" {
+		t.Fatalf("interactive shell should exit normally: %v", err)
+	}
+}
+
+func mustContainer(t *testing.T, cmd string) *MockContainer {
+	ctr, err := NewMockContainer(cmd)
+	if err != nil {
+		t.Fatalf("Create container fail at command %s : %v", cmd, err)
+	}
+	return &ctr
+}
@@ -0,0 +1,153 @@
+package DockerRun
+
+import "strings"
+
+//Exercise is a docker run question with its answer and the settings about what is acceptable,
+//the answer can also be another docker command such as docker build, it is judged like JudgeCommand() then
+type Exercise struct {
+	Answer      string       //the standard docker run command, or another docker command
+	AllowCreate bool         //accept 'docker create' in place of 'docker run'
+	Dialect     string       //the shell that the student use, such as DialectPowerShell, the default one is bash
+	AllowedCLIs []string     //the command line tools can be used such as podman, only docker is allowed if it is empty
+	Version     string       //the docker version of the lab machines such as 'docker 20.10', all flags are allowed if it is empty
+	Policy      *Policy      //the security policy of the exercise or the course, nothing is blocked if it is nil
+	Suppress    []string     //the id of best practices that not reported in this exercise, such as BP001
+	Command     CommandMatch //the way to compare the command and arguments, they are compared token by token by default
+	Locale      string       //the language of the judge result such as LocaleChinese, the default one is English
+	Catalog     *Catalog     //the wording overridden by the course staff, the built-in messages are used if it is nil
+}
+
+//Result is the result of a command judged by the exercise
+type Result struct {
+	Reason   string      //the mistake of the command, it is empty if the command is accepted
+	Warnings []string    //the deprecation and semantic warnings found when parsing
+	Lint     []LintIssue //the best practices that the command break, except those suppressed by the exercise
+	Hints    []string    //the guess of what the student want to write, such as 'did you mean --rm?'
+	Fixed    string      //the corrected command that can be parsed, it is empty if it can't be corrected
+}
+
+//judge a command of student by the exercise,
+//return a string to describe the mistake or a null string if it command is accepted
+func (this *Exercise) Judge(dockerCmd string) string {
+	return this.Check(dockerCmd).Reason
+}
+
+//judge a command of student by the exercise and report the warnings and best practices alongside the judge result
+func (this *Exercise) Check(dockerCmd string) Result {
+	return this.CheckIn(dockerCmd, this.Locale)
+}
+
+//the same as Check but the judge result is in the given locale, so that each student can choose the language
+func (this *Exercise) CheckIn(dockerCmd, locale string) Result {
+	catalog := this.Catalog
+	if catalog == nil {
+		catalog = defaultCatalog
+	}
+	checked := this.check(dockerCmd)
+	result := Result{
+		Reason:   catalog.Render(locale, checked.reason),
+		Warnings: catalog.RenderAll(locale, checked.warnings),
+		Lint:     []LintIssue{},
+		Hints:    catalog.RenderAll(locale, checked.hints),
+		Fixed:    checked.fixed,
+	}
+	for _, issue := range checked.lint {
+		result.Lint = append(result.Lint, issue.localize(catalog, locale))
+	}
+	return result
+}
+
+//the result of an exercise before it is rendered in a locale
+type checkResult struct {
+	reason   Message
+	warnings []Message
+	lint     []LintIssue
+	hints    []Message
+	fixed    string
+}
+
+//check if the answer can be parsed and the settings of the exercise are right,
+//it should be called when the exercise is built so that the mistakes of the exercise are not reported to students
+func (this *Exercise) Validate() error {
+	if _, reason := this.answer(); !reason.IsEmpty() {
+		return reason
+	}
+	return nil
+}
+
+//parse the answer and check the settings of the exercise
+func (this *Exercise) answer() (MockContainer, Message) {
+	var ans MockContainer
+	if !this.isRun() {
+		if reason := judgeCommand(this.Answer, this.Answer); !reason.IsEmpty() {
+			return ans, reason
+		}
+	} else if parsed, err := NewMockContainer(this.Answer); err != nil {
+		return ans, msg("exercise.answer", toMessage(err))
+	} else {
+		ans = parsed
+	}
+	if reason := this.Command.validate(); !reason.IsEmpty() {
+		return ans, msg("exercise.config", reason)
+	}
+	return ans, Message{}
+}
+
+//check if the answer is a docker run command, an answer that can not be recognized is parsed as docker run to report its mistake
+func (this *Exercise) isRun() bool {
+	kind := commandKind(this.Answer)
+	return kind == "run" || kind == ""
+}
+
+//judge a command of student by the exercise, the messages are rendered by CheckIn()
+func (this *Exercise) check(dockerCmd string) checkResult {
+	ans, reason := this.answer()
+	if !reason.IsEmpty() {
+		return checkResult{reason: reason}
+	}
+	dockerCmd, err := ToBash(dockerCmd, this.Dialect)
+	if err != nil {
+		return checkResult{reason: toMessage(err)}
+	}
+	if !this.isRun() {
+		return checkResult{reason: judgeCommand(dockerCmd, this.Answer)}
+	}
+	test, err := NewMockContainerForVersion(dockerCmd, this.Version)
+	if err != nil {
+		hints, fixed := suggest(dockerCmd)
+		return checkResult{reason: toMessage(err), hints: hints, fixed: fixed}
+	}
+	result := checkResult{warnings: test.Warnings, lint: Lint(&test, this.Suppress)}
+	allowed := this.AllowedCLIs
+	if len(allowed) == 0 {
+		allowed = []string{CLIDocker}
+	}
+	if !findInArray(allowed, test.cli()) {
+		result.reason = msg("exercise.cli", test.cli(), strings.Join(allowed, " or "))
+		return result
+	}
+	if this.Policy != nil {
+		if violations := this.Policy.Evaluate(&test); len(violations) > 0 {
+			result.reason = violations[0].reason()
+			return result
+		}
+	}
+	if this.AllowCreate && test.IsCreate && !ans.IsCreate {
+		test.IsCreate = false
+		test.IsDetach = ans.IsDetach //a created container is never attached
+	}
+	if this.Command.isExact() {
+		result.reason = judge(&test, &ans)
+	} else { //the command is compared after the other options
+		command, arg := test.Command, test.Arg
+		test.Command, test.Arg = ans.Command, ans.Arg
+		if result.reason = judge(&test, &ans); result.reason.IsEmpty() {
+			test.Command, test.Arg = command, arg
+			result.reason = this.Command.judge(&test, &ans)
+		}
+	}
+	if !result.reason.IsEmpty() {
+		result.hints = suggestByAnswer(&test, &ans)
+	}
+	return result
+}
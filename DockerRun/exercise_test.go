@@ -0,0 +1,39 @@
+package DockerRun
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExercise(t *testing.T) {
+	exercise := Exercise{Answer: `docker run -d --name server1 -p 80:80 nginx`}
+	if res := exercise.Judge(`docker container run -d -p 80:80 --name server1 nginx`); res != "" {
+		t.Fatalf("docker container run should pass: %s", res)
+	}
+	if exercise.Judge(`docker create --name server1 -p 80:80 nginx`) == "" {
+		t.Fatalf("docker create should not pass without AllowCreate")
+	}
+	exercise.AllowCreate = true
+	if res := exercise.Judge(`docker create --name server1 -p 80:80 nginx`); res != "" {
+		t.Fatalf("docker create should pass with AllowCreate: %s", res)
+	}
+	if exercise.Judge(`docker create --name server1 nginx`) == "" {
+		t.Fatalf("docker create without port should not pass")
+	}
+	exercise = Exercise{Answer: `docker create --name server1 nginx`, AllowCreate: true}
+	if exercise.Judge(`docker run -d --name server1 nginx`) == "" {
+		t.Fatalf("docker run should not pass a docker create answer")
+	}
+}
+
+func TestExerciseHints(t *testing.T) {
+	exercise := Exercise{Answer: `docker run -d -p 8080:80 --name web nginx`}
+	result := exercise.Check(`docker run -d -p 8080:80 -name web nginx`)
+	if result.Reason == "" || result.Fixed != `docker run -d -p 8080:80 --name web nginx` {
+		t.Fatalf("worng correction: %v", result)
+	}
+	result = exercise.Check(`docker run -d -p 80:8080 --name web nginx`)
+	if len(result.Hints) != 1 || !strings.Contains(result.Hints[0], "-p 8080:80") {
+		t.Fatalf("worng hints: %v", result.Hints)
+	}
+}
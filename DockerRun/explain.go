@@ -0,0 +1,259 @@
+package DockerRun
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+//the kinds of the parts of a command in an explanation
+const (
+	PartCLI        = "cli"
+	PartGlobal     = "global"
+	PartSubcommand = "subcommand"
+	PartFlag       = "flag"
+	PartImage      = "image"
+	PartCommand    = "command"
+	PartArgument   = "argument"
+)
+
+//the long name of the short flags of docker run
+var shortFlagNames = map[string]string{
+	"p": "publish", "P": "publish-all", "v": "volume", "i": "interactive", "d": "detach", "t": "tty", "w": "workdir",
+	"u": "user", "a": "attach", "c": "cpu-shares", "e": "env", "h": "hostname", "l": "label", "m": "memory",
+}
+
+//ExplainPart is a token or a flag with its argument of a command and what it does
+type ExplainPart struct {
+	Token   string `json:"token"`
+	Kind    string `json:"kind"`
+	Meaning string `json:"meaning"`
+}
+
+//Explanation is the parts of a docker run command in the order they appear
+type Explanation []ExplainPart
+
+//explain what each part of a docker run command do, such as '-p 8081:8080' publishes container port 8080 on host port 8081,
+//return error if the command have a worng syntax
+func Explain(dockerCmd string) (Explanation, error) {
+	ctr, err := NewMockContainer(dockerCmd)
+	if err != nil {
+		return nil, err
+	}
+	cmd := splitCommand(dockerCmd)
+	parts := Explanation{{cmd[0], PartCLI, fmt.Sprintf("the %s command line client", cmd[0])}}
+	stripped, _ := stripGlobalOptions(cmd, &ClientConfig{})
+	if global := len(cmd) - len(stripped); global > 0 {
+		parts = append(parts, ExplainPart{strings.Join(cmd[1:1+global], " "), PartGlobal, explainClient(&ctr.Client)})
+	}
+	subcommand := strings.Join(stripped[1:runOptionStart(stripped)], " ")
+	if ctr.IsCreate {
+		parts = append(parts, ExplainPart{subcommand, PartSubcommand, "creates a new container without starting it"})
+	} else {
+		parts = append(parts, ExplainPart{subcommand, PartSubcommand, "creates and starts a new container"})
+	}
+	cmd = normalizeCommand(stripped)
+	flagSet := runFlagsOf(cmd[0])
+	nowAt, _ := parseOptions(cmd, 2, flagSet, &explainer{})
+	for i := 2; i < nowAt; { //each option is explained with its tokens as they are written, such as -it and --name=web
+		end := i + optionSpan(cmd[i], flagSet)
+		if end > nowAt {
+			end = nowAt
+		}
+		explainer := &explainer{}
+		parseOptions(cmd[:end], i, flagSet, explainer)
+		meaning := strings.Join(explainer.meanings, "; ")
+		if meaning == "" { //such as --rm=false
+			meaning = "turns the flag off"
+		}
+		parts = append(parts, ExplainPart{strings.Join(cmd[i:end], " "), PartFlag, meaning})
+		i = end
+	}
+	parts = append(parts, ExplainPart{cmd[nowAt], PartImage, fmt.Sprintf("the container is created from the image %s", ctr.Images)})
+	if ctr.Command != "" {
+		parts = append(parts, ExplainPart{ctr.Command, PartCommand, fmt.Sprintf("runs %s in the container instead of the default command of the image", ctr.Command)})
+	}
+	for _, arg := range ctr.Arg {
+		parts = append(parts, ExplainPart{arg, PartArgument, fmt.Sprintf("an argument passed to %s", ctr.Command)})
+	}
+	return parts, nil
+}
+
+//return the explanation as lines of token and meaning
+func (this Explanation) Text() string {
+	width := 0
+	for _, part := range this {
+		if len(part.Token) > width {
+			width = len(part.Token)
+		}
+	}
+	var builder strings.Builder
+	for _, part := range this {
+		fmt.Fprintf(&builder, "%-*s  %s\n", width, part.Token, part.Meaning)
+	}
+	return builder.String()
+}
+
+//return the explanation as a json array
+func (this Explanation) JSON() (string, error) {
+	data, err := json.MarshalIndent(this, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+//explainer record the meaning of the flags when they are handled
+type explainer struct {
+	meanings []string
+}
+
+//record the meaning of a flag with argument
+func (this *explainer) HandleArgument(flag, arg string) error {
+	this.meanings = append(this.meanings, explainFlag(longFlagName(flag), trimStr(arg)))
+	return nil
+}
+
+//record the meaning of a flag without argument
+func (this *explainer) HandleFlag(flag string) error {
+	this.meanings = append(this.meanings, explainFlag(longFlagName(flag), ""))
+	return nil
+}
+
+//return the number of tokens of the option start with token, it is 2 if the flag take the next token as its argument
+func optionSpan(token string, flagSet FlagSet) int {
+	if strings.HasPrefix(token, "--") {
+		if strings.Contains(token, "=") || findInArray(flagSet.NoArg, token[2:]) {
+			return 1
+		}
+		return 2
+	}
+	flags := strings.TrimLeft(token, "-")
+	for i := 0; i < len(flags); i++ {
+		if findInArray(flagSet.NoArg, flags[i:i+1]) {
+			if i+1 < len(flags) && flags[i+1] == '=' { //such as -t=true
+				return 1
+			}
+			continue
+		}
+		if i+1 < len(flags) { //such as -p8080:80
+			return 1
+		}
+		return 2
+	}
+	return 1
+}
+
+//return the long name of a flag such as publish for p
+func longFlagName(flag string) string {
+	if name, have := shortFlagNames[flag]; have {
+		return name
+	}
+	return flag
+}
+
+//return what a flag of docker run do with the argument
+func explainFlag(flag, arg string) string {
+	switch flag {
+	case "publish":
+		ports := strings.Split(arg, ":")
+		return fmt.Sprintf("publishes container port %s on host port %s", ports[len(ports)-1], ports[0])
+	case "publish-all":
+		return "publishes all the ports exposed by the image on random host ports"
+	case "volume":
+		parts := strings.Split(arg, ":")
+		if len(parts) == 1 {
+			return fmt.Sprintf("creates an anonymous volume at %s in the container", arg)
+		}
+		meaning := fmt.Sprintf("mounts the named volume %s at %s in the container", parts[0], parts[1])
+		if strings.HasPrefix(parts[0], "/") || strings.HasPrefix(parts[0], "$") || strings.HasPrefix(parts[0], ".") {
+			meaning = fmt.Sprintf("mounts the host path %s at %s in the container", parts[0], parts[1])
+		}
+		if len(parts) > 2 && findInArray(strings.Split(parts[2], ","), "ro") {
+			meaning += ", read-only"
+		}
+		return meaning
+	case "mount":
+		mount, err := parseMount(arg)
+		if err != nil {
+			return fmt.Sprintf("mounts %s", arg)
+		}
+		if mount["source"] == "" {
+			return fmt.Sprintf("mounts a %s at %s in the container", mount["type"], mount["target"])
+		}
+		return fmt.Sprintf("mounts the %s %s at %s in the container", mount["type"], mount["source"], mount["target"])
+	case "env":
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) == 1 {
+			return fmt.Sprintf("passes the environment variable %s of the host into the container", kv[0])
+		}
+		return fmt.Sprintf("sets the environment variable %s to %s", kv[0], kv[1])
+	case "name":
+		return fmt.Sprintf("names the container %s", arg)
+	case "hostname":
+		return fmt.Sprintf("sets the hostname inside the container to %s", arg)
+	case "user":
+		return fmt.Sprintf("runs the processes of the container as user %s", arg)
+	case "workdir":
+		return fmt.Sprintf("sets the working directory inside the container to %s", arg)
+	case "detach":
+		return "runs the container in the background and prints its ID"
+	case "interactive":
+		return "keeps STDIN open so that you can type into the container"
+	case "tty":
+		return "allocates a pseudo-terminal for the container"
+	case "rm":
+		return "removes the container automatically when it exits"
+	case "privileged":
+		return "gives the container all the capabilities and access to the devices of the host"
+	case "attach":
+		return fmt.Sprintf("attaches to the %s of the container", arg)
+	case "cpu-shares":
+		return fmt.Sprintf("sets the relative CPU weight of the container to %s", arg)
+	case "memory":
+		return fmt.Sprintf("limits the memory of the container to %s", arg)
+	case "kernel-memory":
+		return fmt.Sprintf("limits the kernel memory of the container to %s", arg)
+	case "label":
+		return fmt.Sprintf("adds the metadata %s to the container", arg)
+	case "link":
+		return fmt.Sprintf("links to the container %s so that it can be reached by name", strings.Split(arg, ":")[0])
+	case "network":
+		return fmt.Sprintf("connects the container to the network %s", arg)
+	case "restart":
+		return fmt.Sprintf("restarts the container by the policy %s when it exits", arg)
+	case "userns":
+		return fmt.Sprintf("sets the user namespace mode to %s", arg)
+	case "pod":
+		return fmt.Sprintf("runs the container in the pod %s", arg)
+	case "platform":
+		return fmt.Sprintf("uses the image for the platform %s", arg)
+	case "pull":
+		return fmt.Sprintf("pulls the image by the policy %s before creating the container", arg)
+	case "gpus":
+		return fmt.Sprintf("gives the container access to the GPUs %s", arg)
+	case "pid":
+		return fmt.Sprintf("uses the PID namespace %s", arg)
+	case "cap-add":
+		return fmt.Sprintf("adds the Linux capability %s", arg)
+	case "cap-drop":
+		return fmt.Sprintf("drops the Linux capability %s", arg)
+	case "device":
+		return fmt.Sprintf("gives the container access to the host device %s", strings.Split(arg, ":")[0])
+	}
+	if arg == "" {
+		return fmt.Sprintf("sets --%s", flag)
+	}
+	return fmt.Sprintf("sets --%s to %s", flag, arg)
+}
+
+//return what the global options of the docker client do
+func explainClient(client *ClientConfig) string {
+	if len(client.Hosts) > 0 {
+		return fmt.Sprintf("connects to the docker daemon at %s", strings.Join(client.Hosts, ", "))
+	}
+	if client.Context != "" {
+		return fmt.Sprintf("uses the docker context %s", client.Context)
+	}
+	return "sets the options of the docker client"
+}
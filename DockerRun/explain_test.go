@@ -0,0 +1,50 @@
+package DockerRun
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	parts, err := Explain(`docker -H tcp://lab:2375 container run -d -it -p 8081:8080 -v $PWD:/app:ro --name=web nginx:1.25 nginx -g daemon`)
+	if err != nil {
+		t.Fatalf("explain fail: %v", err)
+	}
+	expect := []ExplainPart{
+		{"docker", PartCLI, "the docker command line client"},
+		{"-H tcp://lab:2375", PartGlobal, "connects to the docker daemon at tcp://lab:2375"},
+		{"container run", PartSubcommand, "creates and starts a new container"},
+		{"-d", PartFlag, "runs the container in the background and prints its ID"},
+		{"-it", PartFlag, "keeps STDIN open so that you can type into the container; allocates a pseudo-terminal for the container"},
+		{"-p 8081:8080", PartFlag, "publishes container port 8080 on host port 8081"},
+		{"-v $PWD:/app:ro", PartFlag, "mounts the host path $PWD at /app in the container, read-only"},
+		{"--name=web", PartFlag, "names the container web"},
+		{"nginx:1.25", PartImage, "the container is created from the image nginx:1.25"},
+		{"nginx", PartCommand, "runs nginx in the container instead of the default command of the image"},
+		{"-g", PartArgument, "an argument passed to nginx"},
+		{"daemon", PartArgument, "an argument passed to nginx"},
+	}
+	if len(parts) != len(expect) {
+		t.Fatalf("worng number of parts: %v", parts)
+	}
+	for i := range expect {
+		if parts[i] != expect[i] {
+			t.Fatalf("worng part %d: %v", i, parts[i])
+		}
+	}
+	if text := parts.Text(); !strings.Contains(text, "-p 8081:8080       publishes container port 8080 on host port 8081\n") {
+		t.Fatalf("worng text:\n%s", text)
+	}
+	data, err := parts.JSON()
+	if err != nil {
+		t.Fatalf("json fail: %v", err)
+	}
+	decoded := Explanation{}
+	if err = json.Unmarshal([]byte(data), &decoded); err != nil || len(decoded) != len(parts) || decoded[5].Token != "-p 8081:8080" {
+		t.Fatalf("worng json: %s", data)
+	}
+	if _, err = Explain(`docker run --rmv nginx`); err == nil {
+		t.Fatalf("worng command is explained")
+	}
+}
@@ -0,0 +1,410 @@
+package DockerRun
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+//the default registry and the namespace of official images
+const (
+	defaultRegistry  = "docker.io"
+	officialRepoName = "library"
+)
+
+//ImageFlags is all flags allowled to used in the commands that manage images
+var ImageFlags = map[string]FlagSet{
+	"pull": {
+		Short: []string{"a", "q"},
+		Long:  []string{"all-tags", "quiet", "platform"},
+		NoArg: []string{"a", "all-tags", "q", "quiet"},
+	},
+	"push": {
+		Short: []string{"a", "q"},
+		Long:  []string{"all-tags", "quiet"},
+		NoArg: []string{"a", "all-tags", "q", "quiet"},
+	},
+	"tag": {},
+	"rmi": {
+		Short: []string{"f"},
+		Long:  []string{"force", "no-prune"},
+		NoArg: []string{"f", "force", "no-prune"},
+	},
+	"images": {
+		Short: []string{"a", "q", "f"},
+		Long:  []string{"all", "quiet", "filter", "format", "digests", "no-trunc"},
+		NoArg: []string{"a", "all", "q", "quiet", "digests", "no-trunc"},
+	},
+	"save": {Short: []string{"o"}, Long: []string{"output"}},
+	"load": {
+		Short: []string{"i", "q"},
+		Long:  []string{"input", "quiet"},
+		NoArg: []string{"q", "quiet"},
+	},
+	"prune": {
+		Short: []string{"a", "f"},
+		Long:  []string{"all", "force", "filter"},
+		NoArg: []string{"a", "all", "f", "force"},
+	},
+}
+
+//the name of the image commands that used after 'docker image', such as docker image ls
+var imageManagementActions = map[string]string{
+	"pull": "pull", "push": "push", "tag": "tag", "rm": "rmi", "ls": "images", "list": "images",
+	"save": "save", "load": "load", "prune": "prune",
+}
+
+//the model to simulate the property of a command that manage images: pull, push, tag, rmi, images, save, load, image prune
+//the images in it have been normalized, such as alpine is docker.io/library/alpine:latest
+type MockImage struct {
+	Action    string
+	Images    []string
+	Filters   []string
+	Format    string
+	Output    string
+	Input     string
+	Platform  string
+	IsAll     bool
+	IsQuiet   bool
+	IsForce   bool
+	IsAllTags bool
+	Client    ClientConfig //the global options before the subcommand
+}
+
+//create an MockImage according to a docker pull/push/tag/rmi/images/save/load/image command,
+//return error if it command have a worng syntax
+func NewMockImage(dockerCmd string) (model MockImage, err error) {
+	cmdArray := splitCommand(dockerCmd)
+	if reason := model.basicCheck(cmdArray); !reason.IsEmpty() {
+		return model, reason
+	}
+	return model, nil
+}
+
+//check the basic syntax of a command that manage images,
+//return the fall reason or return a empty string if the command is accpeted
+//synatax: docker pull|push [OPTIONS] NAME[:TAG], docker tag SOURCE_IMAGE[:TAG] TARGET_IMAGE[:TAG],
+//docker rmi|save [OPTIONS] IMAGE [IMAGE...], docker images [OPTIONS] [REPOSITORY[:TAG]],
+//docker load [OPTIONS] and docker image prune [OPTIONS]
+func (this *MockImage) BasicCheck(cmd []string) string {
+	return this.basicCheck(cmd).String()
+}
+
+//check the basic syntax of a command that manage images, return the fall reason or a empty message if the command is accpeted
+func (this *MockImage) basicCheck(cmd []string) Message {
+	if len(cmd) == 0 {
+		return msg("run.empty")
+	}
+	if len(cmd) < 2 {
+		return msg("run.too_short")
+	}
+	if cmd[0] != "docker" {
+		return msg("run.not_docker")
+	}
+	cmd, reason := stripGlobalOptions(cmd, &this.Client)
+	if !reason.IsEmpty() {
+		return reason
+	}
+	nowAt := 2
+	this.Action = cmd[1]
+	if cmd[1] == "image" {
+		if len(cmd) < 3 || imageManagementActions[cmd[2]] == "" {
+			return msg("command.not_kind", "image management")
+		}
+		this.Action = imageManagementActions[cmd[2]]
+		nowAt = 3
+	} else if this.Action == "prune" {
+		return msg("command.not_kind", "image management")
+	}
+	flagSet, have := ImageFlags[this.Action]
+	if !have {
+		return msg("command.not_kind", "image management")
+	}
+	nowAt, reason = parseOptions(cmd, nowAt, flagSet, this)
+	if !reason.IsEmpty() {
+		return reason
+	}
+	for _, name := range cmd[nowAt:] {
+		name = trimStr(name)
+		ref, err := NormalizeImageRef(name)
+		if err != nil {
+			return toMessage(err)
+		}
+		this.Images = append(this.Images, ref)
+	}
+	count := len(this.Images)
+	switch this.Action {
+	case "pull", "push":
+		if count != 1 {
+			return msg("command.exactly_one", this.Action)
+		}
+	case "tag":
+		if count != 2 {
+			return msg("command.exactly_two", "tag")
+		}
+	case "rmi", "save":
+		if count == 0 {
+			return msg("command.at_least_one", this.Action)
+		}
+	case "images":
+		if count > 1 {
+			return msg("command.at_most_one", "images")
+		}
+	case "load", "prune":
+		if count > 0 {
+			return msg("command.no_arg", this.Action)
+		}
+	}
+	return Message{}
+}
+
+//Setting up the property according to the flag and argument
+//if the format of arguments not right it will return error
+func (this *MockImage) HandleArgument(flag, arg string) error {
+	arg = trimStr(arg)
+	switch flag {
+	case "f", "filter":
+		if !strings.Contains(arg, "=") {
+			return msg("arg.filter")
+		}
+		this.Filters = append(this.Filters, arg)
+	case "format":
+		this.Format = arg
+	case "o", "output":
+		this.Output = arg
+	case "i", "input":
+		this.Input = arg
+	case "platform":
+		if !isPlatform(arg) {
+			return msg("arg.platform", arg)
+		}
+		this.Platform = arg
+	default:
+		return msg("flag.invalid", flag)
+	}
+	return nil
+}
+
+//Setting up the property according to the flag that without argument
+//return error only if the flag is not exist
+func (this *MockImage) HandleFlag(flag string) error {
+	switch flag {
+	case "a", "all", "all-tags":
+		if this.Action == "pull" || this.Action == "push" {
+			this.IsAllTags = true
+		} else {
+			this.IsAll = true
+		}
+	case "q", "quiet":
+		this.IsQuiet = true
+	case "f", "force":
+		this.IsForce = true
+	case "no-prune", "digests", "no-trunc":
+	default:
+		return msg("flag.invalid_short", flag)
+	}
+	return nil
+}
+
+//judge if the property of a command that manage images is right by compared to the answer
+//return a string to describe the mistake or a null string if it command is accepted
+func JudgeImage(test, ans *MockImage) string {
+	return judgeImage(test, ans).String()
+}
+
+//judge the property of a command that manage images, return the mistake or a empty message if it command is accepted
+func judgeImage(test, ans *MockImage) Message {
+	if test == nil {
+		return msg("judge.null_test")
+	}
+	if ans == nil {
+		return msg("judge.null_ans")
+	}
+	if test.Action != ans.Action {
+		return msg("judge.action", ans.Action, test.Action)
+	}
+	if ans.Action == "tag" { //the order of source and target is important
+		if len(test.Images) != len(ans.Images) {
+			return msg("judge.images_number", len(ans.Images), len(test.Images))
+		}
+		for i := range ans.Images {
+			if test.Images[i] != ans.Images[i] {
+				return msg("judge.images", FamiliarImageRef(ans.Images[i]), FamiliarImageRef(test.Images[i]))
+			}
+		}
+	}
+	for _, ref := range ans.Images {
+		if !findInArray(test.Images, ref) {
+			return msg("judge.images_missing", FamiliarImageRef(ref))
+		}
+	}
+	for _, ref := range test.Images {
+		if !findInArray(ans.Images, ref) {
+			return msg("judge.unexpect_images", FamiliarImageRef(ref))
+		}
+	}
+	for _, filter := range ans.Filters {
+		if !findInArray(test.Filters, filter) {
+			return msg("judge.filter", filter)
+		}
+	}
+	if ans.IsAll && !test.IsAll {
+		return msg("judge.flag", "-a or --all")
+	}
+	if ans.IsAllTags && !test.IsAllTags {
+		return msg("judge.flag", "-a or --all-tags")
+	}
+	if ans.IsQuiet && !test.IsQuiet {
+		return msg("judge.flag", "-q or --quiet")
+	}
+	if ans.IsForce && !test.IsForce {
+		return msg("judge.flag", "-f or --force")
+	}
+	if ans.Output != "" && path.Clean(test.Output) != path.Clean(ans.Output) {
+		return msg("judge.output", ans.Output, test.Output)
+	}
+	if ans.Input != "" && path.Clean(test.Input) != path.Clean(ans.Input) {
+		return msg("judge.input", ans.Input, test.Input)
+	}
+	if ans.Platform != "" && test.Platform != ans.Platform {
+		return msg("judge.platform", ans.Platform, test.Platform)
+	}
+	if ans.Format != "" && test.Format != ans.Format {
+		return msg("judge.format", ans.Format, test.Format)
+	}
+	if reason := judgeClient(&test.Client, &ans.Client); !reason.IsEmpty() {
+		return reason
+	}
+	return Message{}
+}
+
+//apply a command that manage images to the image store of engine, return the output of the command
+func (this *MockEngine) ManageImage(m *MockImage) (string, error) {
+	output := ""
+	switch m.Action {
+	case "pull":
+		this.Images[m.Images[0]] = true
+		output = m.Images[0] + "\n"
+	case "push":
+		if !this.Images[m.Images[0]] {
+			return "", fmt.Errorf("An image does not exist locally with the tag: %s", strings.Split(FamiliarImageRef(m.Images[0]), ":")[0])
+		}
+	case "tag":
+		if !this.Images[m.Images[0]] {
+			return "", fmt.Errorf("No such image: %s", FamiliarImageRef(m.Images[0]))
+		}
+		this.Images[m.Images[1]] = true
+	case "rmi":
+		for _, ref := range m.Images {
+			if !this.Images[ref] {
+				return output, fmt.Errorf("No such image: %s", FamiliarImageRef(ref))
+			}
+			if c := this.imageUser(ref); c != nil && !m.IsForce {
+				return output, fmt.Errorf("conflict: unable to remove repository reference \"%s\" (must force) - container %s is using its referenced image", FamiliarImageRef(ref), c.ID[:12])
+			}
+			delete(this.Images, ref)
+			output += "Untagged: " + FamiliarImageRef(ref) + "\n"
+		}
+	case "images":
+		if !m.IsQuiet {
+			output = fmt.Sprintf("%-40s   %s\n", "REPOSITORY", "TAG")
+		}
+		for _, ref := range sortedSet(this.Images) {
+			if len(m.Images) > 0 && ref != m.Images[0] {
+				continue
+			}
+			if strings.HasPrefix(ref, "<none>") && !m.IsAll {
+				continue
+			}
+			name := FamiliarImageRef(ref)
+			index := strings.LastIndex(name, ":")
+			if m.IsQuiet {
+				output += name + "\n"
+			} else {
+				output += fmt.Sprintf("%-40s   %s\n", name[:index], name[index+1:])
+			}
+		}
+	case "save":
+		if m.Output == "" {
+			return "", fmt.Errorf("cowardly refusing to save to a terminal. Use the -o flag or redirect")
+		}
+		for _, ref := range m.Images {
+			if !this.Images[ref] {
+				return "", fmt.Errorf("reference does not exist: %s", FamiliarImageRef(ref))
+			}
+		}
+		if this.archives == nil {
+			this.archives = make(map[string][]string)
+		}
+		this.archives[path.Clean(m.Output)] = m.Images
+	case "load":
+		refs, have := this.archives[path.Clean(m.Input)]
+		if m.Input == "" || !have {
+			return "", fmt.Errorf("open %s: no such file or directory", m.Input)
+		}
+		for _, ref := range refs {
+			this.Images[ref] = true
+			output += "Loaded image: " + FamiliarImageRef(ref) + "\n"
+		}
+	case "prune":
+		for _, ref := range sortedSet(this.Images) {
+			if (strings.HasPrefix(ref, "<none>") || m.IsAll) && this.imageUser(ref) == nil {
+				delete(this.Images, ref)
+				output += "deleted: " + FamiliarImageRef(ref) + "\n"
+			}
+		}
+	}
+	return output, nil
+}
+
+//return true if the engine have the images, the reference is normalized before checking
+func (this *MockEngine) HasImage(ref string) bool {
+	normalized, err := NormalizeImageRef(ref)
+	return err == nil && this.Images[normalized]
+}
+
+//return a container that use the images, return nil if not found
+func (this *MockEngine) imageUser(ref string) *EngineContainer {
+	for _, c := range this.Containers {
+		if normalized, _ := NormalizeImageRef(c.Config.Images); normalized == ref {
+			return c
+		}
+	}
+	return nil
+}
+
+//change a reference of images into the full form, such as alpine into docker.io/library/alpine:latest
+//and username/app into docker.io/username/app:latest, return error if it is not a legal reference
+func NormalizeImageRef(ref string) (string, error) {
+	refReg := regexp.MustCompile(`^(([a-zA-Z0-9.-]+(:\d+)?)/)?([a-z0-9]+([._-][a-z0-9]+)*(/[a-z0-9]+([._-][a-z0-9]+)*)*)(:([\w][\w.-]{0,127}))?(@sha256:[a-f0-9]{64})?$`)
+	match := refReg.FindStringSubmatch(ref)
+	if match == nil {
+		return "", msg("arg.reference", ref)
+	}
+	domain, repo, tag, digest := match[2], match[4], match[9], match[10]
+	if domain != "" && !strings.ContainsAny(domain, ".:") && domain != "localhost" { //such as username/app, the first part is not a domain
+		repo = domain + "/" + repo
+		domain = ""
+	}
+	if domain == "" || domain == "index.docker.io" {
+		domain = defaultRegistry
+	}
+	if domain == defaultRegistry && !strings.Contains(repo, "/") {
+		repo = officialRepoName + "/" + repo
+	}
+	if tag == "" && digest == "" {
+		tag = "latest"
+	}
+	normalized := domain + "/" + repo
+	if tag != "" {
+		normalized += ":" + tag
+	}
+	return normalized + digest, nil
+}
+
+//change a normalized reference of images into the short form that shown by docker cli, such as alpine:latest
+func FamiliarImageRef(ref string) string {
+	ref = strings.TrimPrefix(ref, defaultRegistry+"/")
+	return strings.TrimPrefix(ref, officialRepoName+"/")
+}
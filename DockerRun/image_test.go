@@ -0,0 +1,114 @@
+package DockerRun
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeImageRef(t *testing.T) {
+	cases := map[string]string{
+		`alpine`:                         `docker.io/library/alpine:latest`,
+		`alpine:3.10`:                    `docker.io/library/alpine:3.10`,
+		`username/app`:                   `docker.io/username/app:latest`,
+		`docker.io/username/app:latest`:  `docker.io/username/app:latest`,
+		`index.docker.io/library/alpine`: `docker.io/library/alpine:latest`,
+		`localhost:5000/app:v1`:          `localhost:5000/app:v1`,
+		`quay.io/coreos/etcd`:            `quay.io/coreos/etcd:latest`,
+	}
+	for ref, expect := range cases {
+		if normalized, err := NormalizeImageRef(ref); err != nil || normalized != expect {
+			t.Fatalf("normalize %s expect %s but got %s, %v", ref, expect, normalized, err)
+		}
+	}
+	for ref := range cases {
+		if !isImagesName(ref) {
+			t.Fatalf("legal reference %s is rejected by docker run", ref)
+		}
+	}
+	for _, ref := range []string{`alpine:`, `Alpine`, `app::v1`, ``} {
+		if _, err := NormalizeImageRef(ref); err == nil || isImagesName(ref) {
+			t.Fatalf("invalid reference %s should return error", ref)
+		}
+	}
+	if ctr, err := NewMockContainer(`docker run localhost:5000/app`); err != nil || ctr.Images != `localhost:5000/app:latest` || ctr.IsTagged {
+		t.Fatalf("the port of registry is not a tag: %s %v", ctr.Images, err)
+	}
+	if FamiliarImageRef(cases[`alpine`]) != `alpine:latest` {
+		t.Fatalf("unexpect familiar reference: %s", FamiliarImageRef(cases[`alpine`]))
+	}
+}
+
+func TestJudgeTagImages(t *testing.T) {
+	ans, _ := NewMockImage(`docker tag alpine username/alpine:v1`)
+	test := MockImage{Action: "tag", Images: []string{"docker.io/library/alpine:latest"}}
+	if res := JudgeImage(&test, &ans); res == "" {
+		t.Fatalf("tag with one images should not pass")
+	}
+}
+
+func TestMockImage(t *testing.T) {
+	cases := map[string][]string{
+		`docker tag app:latest docker.io/username/app:latest`: {`docker tag app username/app`, `docker image tag app username/app:latest`},
+		`docker pull alpine:latest`:                           {`docker pull alpine`, `docker image pull docker.io/library/alpine`},
+		`docker rmi -f alpine`:                                {`docker image rm --force alpine:latest`},
+		`docker save -o app.tar username/app`:                 {`docker save --output=./app.tar username/app:latest`},
+		`docker image prune -a`:                               {`docker image prune --all --force`},
+	}
+	for standard, challenger := range cases {
+		ans, err := NewMockImage(standard)
+		if err != nil {
+			t.Fatalf("Standard command %s fall: %v", standard, err)
+		}
+		for _, cmd := range challenger {
+			test, err := NewMockImage(cmd)
+			if err != nil {
+				t.Fatalf("Create image command fail at %s : %v", cmd, err)
+			}
+			if res := JudgeImage(&test, &ans); res != "" {
+				t.Fatalf("Unpass at %s : %v", cmd, res)
+			}
+		}
+	}
+	ans, _ := NewMockImage(`docker tag app username/app`)
+	test, _ := NewMockImage(`docker tag username/app app`)
+	if JudgeImage(&test, &ans) == "" {
+		t.Fatalf("swapped source and target should not pass")
+	}
+	for _, cmd := range []string{`docker pull`, `docker tag app`, `docker prune`, `docker image prune alpine`, `docker pull Alpine`} {
+		if _, err := NewMockImage(cmd); err == nil {
+			t.Fatalf("worng command %s pass!", cmd)
+		}
+	}
+}
+
+func TestMockEngineManageImage(t *testing.T) {
+	engine := NewMockEngine()
+	engine.RunCommands([]string{`docker run -d --name web nginx`})
+	steps := []struct {
+		cmd, err string
+	}{
+		{`docker push username/app`, `An image does not exist locally with the tag: username/app`},
+		{`docker pull alpine`, ``},
+		{`docker tag alpine username/app:v1`, ``},
+		{`docker tag busybox username/box`, `No such image: busybox:latest`},
+		{`docker push username/app:v1`, ``},
+		{`docker rmi nginx`, `container ` + engine.Container("web").ID[:12] + ` is using its referenced image`},
+		{`docker save -o app.tar username/app:v1`, ``},
+		{`docker rmi alpine username/app:v1`, ``},
+		{`docker load -i ./app.tar`, ``},
+		{`docker image prune -a -f`, ``},
+	}
+	for _, step := range steps {
+		m, err := NewMockImage(step.cmd)
+		if err != nil {
+			t.Fatalf("Create image command fail at %s : %v", step.cmd, err)
+		}
+		_, err = engine.ManageImage(&m)
+		if (step.err == "" && err != nil) || (step.err != "" && (err == nil || !strings.Contains(err.Error(), step.err))) {
+			t.Fatalf("command '%s' expect error '%s' but got: %v", step.cmd, step.err, err)
+		}
+	}
+	if !engine.HasImage("nginx") || engine.HasImage("alpine") || engine.HasImage("username/app:v1") {
+		t.Fatalf("unexpect images: %v", engine.Images)
+	}
+}
@@ -0,0 +1,215 @@
+package DockerRun
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//the following is a subset of the output of 'docker inspect <container>', only the field that can be used by MockContainer is declared
+
+//InspectContainer is one element of the array printed by docker inspect
+type InspectContainer struct {
+	Id         string
+	Name       string
+	State      InspectState
+	Config     InspectConfig
+	HostConfig InspectHostConfig
+	Mounts     []InspectMount
+}
+
+//InspectState is the State part of docker inspect
+type InspectState struct {
+	Status   string
+	Running  bool
+	ExitCode int
+}
+
+//InspectConfig is the Config part of docker inspect
+type InspectConfig struct {
+	Hostname     string
+	User         string
+	AttachStdin  bool
+	AttachStdout bool
+	AttachStderr bool
+	Tty          bool
+	OpenStdin    bool
+	Env          []string
+	Cmd          []string
+	Image        string
+	WorkingDir   string
+	Labels       map[string]string
+}
+
+//InspectHostConfig is the HostConfig part of docker inspect
+type InspectHostConfig struct {
+	Binds           []string
+	NetworkMode     string
+	RestartPolicy   EngineRestartPolicy
+	PortBindings    map[string][]EnginePortBinding
+	AutoRemove      bool
+	Links           []string
+	PublishAllPorts bool
+	Memory          int64
+	CpuShares       int
+	Privileged      bool
+	PidMode         string
+	CapAdd          []string
+	CapDrop         []string
+	Devices         []EngineDevice
+}
+
+//InspectMount is a element of the Mounts part of docker inspect
+type InspectMount struct {
+	Type        string
+	Name        string
+	Source      string
+	Destination string
+	Mode        string //the options of the volume such as ro,Z, it is z by default for a named volume
+	RW          bool
+}
+
+//create an MockContainer according to the output of 'docker inspect', both a json array and a single object are accepted,
+//pwd is the working directory of the student, the host path under it is replaced to $PWD so that it can be compared with the answer
+func NewMockContainerFromInspect(data []byte, pwd string) (model MockContainer, err error) {
+	model.Port = make(map[string]string)
+	model.Volume = make(map[string]string)
+	model.Env = make(map[string]string)
+	data = bytes.TrimSpace(data)
+	var inspect InspectContainer
+	if bytes.HasPrefix(data, []byte("[")) {
+		list := []InspectContainer{}
+		if err = json.Unmarshal(data, &list); err != nil {
+			return model, fmt.Errorf("Invalid inspect output: %v", err)
+		}
+		if len(list) != 1 {
+			return model, fmt.Errorf("Expect inspect output of one container but got %d", len(list))
+		}
+		inspect = list[0]
+	} else if err = json.Unmarshal(data, &inspect); err != nil {
+		return model, fmt.Errorf("Invalid inspect output: %v", err)
+	}
+	if err = model.loadInspect(&inspect, pwd); err != nil {
+		return model, err
+	}
+	return model, nil
+}
+
+//setting up the property of a container according to the inspect output
+func (this *MockContainer) loadInspect(inspect *InspectContainer, pwd string) error {
+	config, host := inspect.Config, inspect.HostConfig
+	if config.Image == "" {
+		return fmt.Errorf("Can't find images name from inspect output!")
+	}
+	this.Images = withDefaultTag(config.Image)
+	this.IsTagged = hasImageTag(config.Image)
+	if len(config.Cmd) > 0 {
+		this.Command = config.Cmd[0]
+		if len(config.Cmd) > 1 {
+			this.Arg = config.Cmd[1:]
+		}
+	}
+	this.ContainerName = strings.TrimPrefix(inspect.Name, "/")
+	if config.Hostname != "" && !strings.HasPrefix(inspect.Id, config.Hostname) { //the default hostname is the short id
+		this.HostName = config.Hostname
+	}
+	this.User = config.User
+	this.WorkDir = config.WorkingDir
+	this.IsTTY = config.Tty
+	this.IsInteractive = config.OpenStdin
+	this.IsDetach = !config.AttachStdout && !config.AttachStderr
+	for _, e := range config.Env {
+		kv := strings.SplitN(e, "=", 2)
+		if len(kv) == 1 {
+			kv = append(kv, "")
+		}
+		this.Env[kv[0]] = kv[1]
+	}
+	for _, k := range sortedKeys(config.Labels) {
+		this.Label = append(this.Label, k+"="+config.Labels[k])
+	}
+	this.IsRemove = host.AutoRemove
+	this.IsPublishAll = host.PublishAllPorts
+	if host.NetworkMode != "default" && host.NetworkMode != "bridge" {
+		this.NetWork = host.NetworkMode
+	}
+	switch {
+	case host.RestartPolicy.Name == "" || host.RestartPolicy.Name == "no":
+	case host.RestartPolicy.MaximumRetryCount > 0:
+		this.Restart = fmt.Sprintf("%s:%d", host.RestartPolicy.Name, host.RestartPolicy.MaximumRetryCount)
+	default:
+		this.Restart = host.RestartPolicy.Name
+	}
+	for _, l := range host.Links { //such as /database:/web/db
+		parts := strings.Split(l, ":")
+		if len(parts) != 2 {
+			return fmt.Errorf("Invalid link in inspect output: %s", l)
+		}
+		alias := parts[1][strings.LastIndex(parts[1], "/")+1:]
+		this.Link = append(this.Link, strings.TrimPrefix(parts[0], "/")+":"+alias)
+	}
+	this.Memory = int(host.Memory >> 20)
+	this.CpuShare = host.CpuShares
+	this.IsPrivileged = host.Privileged
+	this.Pid = host.PidMode
+	for _, c := range host.CapAdd { //such as CAP_NET_ADMIN, the same form as --cap-add
+		this.CapAdd = append(this.CapAdd, strings.TrimPrefix(strings.ToUpper(c), "CAP_"))
+	}
+	for _, c := range host.CapDrop {
+		this.CapDrop = append(this.CapDrop, strings.TrimPrefix(strings.ToUpper(c), "CAP_"))
+	}
+	for _, d := range host.Devices { //the same form as --device, such as /dev/sda:/dev/xvdc:r
+		device := d.PathOnHost
+		if d.PathInContainer != "" && d.PathInContainer != d.PathOnHost {
+			device += ":" + d.PathInContainer
+		}
+		if d.CgroupPermissions != "" && d.CgroupPermissions != "rwm" {
+			device += ":" + d.CgroupPermissions
+		}
+		this.Device = append(this.Device, device)
+	}
+	for conPort, bindings := range host.PortBindings {
+		conPort = strings.TrimSuffix(conPort, "/tcp")
+		if _, err := strconv.Atoi(conPort); err != nil {
+			return fmt.Errorf("Unsupported port in inspect output: %s", conPort)
+		}
+		for _, b := range bindings {
+			if b.HostPort != "" {
+				this.Port[b.HostPort] = conPort
+			}
+		}
+	}
+	pwd = strings.TrimRight(pwd, "/")
+	for _, m := range inspect.Mounts {
+		if m.Type == "tmpfs" { //the same form as --mount type=tmpfs
+			this.Mount = append(this.Mount, formatMount(map[string]string{"type": m.Type, "target": m.Destination}))
+			continue
+		}
+		source := m.Source
+		if m.Type == "volume" {
+			source = m.Name
+		} else if pwd != "" && (source == pwd || strings.HasPrefix(source, pwd+"/")) { //not a sibling such as /home/a2 of /home/a
+			source = "$PWD" + strings.TrimPrefix(source, pwd)
+		}
+		source = strings.TrimRight(source, "\\/")
+		this.Volume[source] = strings.TrimRight(m.Destination, "\\/")
+		options := []string{}
+		for _, opt := range strings.Split(m.Mode, ",") {
+			if opt != "" && !(m.Type == "volume" && opt == "z") {
+				options = append(options, opt)
+			}
+		}
+		if !m.RW && !findInArray(options, "ro") {
+			options = append(options, "ro")
+		}
+		if len(options) > 0 {
+			if this.VolumeOption == nil {
+				this.VolumeOption = make(map[string]string)
+			}
+			this.VolumeOption[source] = strings.Join(options, ",")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package DockerRun
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestNewMockContainerFromInspect(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/inspect_server.json")
+	if err != nil {
+		t.Fatalf("read fixture fail: %v", err)
+	}
+	live, err := NewMockContainerFromInspect(data, "/home/student")
+	if err != nil {
+		t.Fatalf("load inspect fail: %v", err)
+	}
+	ans, _ := NewMockContainer(`docker run -d -w /data -m 1g -p 8081:8080 --name server3 -v username_vol:/data -v $PWD/conf:/etc/app:ro --mount type=tmpfs,dst=/cache --privileged --pid host --cap-add NET_ADMIN --device /dev/fuse --device /dev/sda:/dev/xvdc:r username/1000010024_server:latest rm hello.txt`)
+	if res := Judge(&live, &ans); res != "" {
+		live.Printf()
+		t.Fatalf("live container should pass: %s", res)
+	}
+	if live.HostName != "" || !live.IsTagged {
+		t.Fatalf("default hostname should be ignored and the tag should be kept but got %s %v", live.HostName, live.IsTagged)
+	}
+	if live.VolumeOption["$PWD/conf"] != "ro" || live.VolumeOption["username_vol"] != "" || len(live.Mount) != 1 {
+		t.Fatalf("worng volume options or mounts: %v %v", live.VolumeOption, live.Mount)
+	}
+	if len(live.CapAdd) != 1 || len(live.Device) != 2 || live.Device[1] != "/dev/sda:/dev/xvdc:r" {
+		t.Fatalf("worng capabilities or devices: %v %v", live.CapAdd, live.Device)
+	}
+	for _, cmd := range []string{
+		`docker run -d -w /data --name server1 -v username_vol:/data username/1000010024_server:latest rm hello.txt`,
+		`docker run -d -w /data -m 1g -p 8081:8080 --name server3 -v username_vol:/data:ro -v $PWD/conf:/etc/app:ro --mount type=tmpfs,dst=/cache --privileged --pid host --cap-add NET_ADMIN --device /dev/fuse --device /dev/sda:/dev/xvdc:r username/1000010024_server:latest rm hello.txt`,
+		`docker run -d -w /data -m 1g -p 8081:8080 --name server3 -v username_vol:/data -v $PWD/conf:/etc/app:ro --mount type=tmpfs,dst=/cache --privileged --pid container:db --cap-add NET_ADMIN --device /dev/fuse --device /dev/sda:/dev/xvdc:r username/1000010024_server:latest rm hello.txt`,
+		`docker run -d -w /data -m 1g -p 8081:8080 --name server3 -v username_vol:/data -v $PWD/conf:/etc/app:ro --mount type=tmpfs,dst=/cache --pid host --cap-add NET_ADMIN --device /dev/fuse --device /dev/sda:/dev/xvdc:r username/1000010024_server:latest rm hello.txt`,
+	} {
+		wrong, _ := NewMockContainer(cmd)
+		if Judge(&live, &wrong) == "" {
+			t.Fatalf("live container should not pass a different answer: %s", cmd)
+		}
+	}
+	if _, err := NewMockContainerFromInspect([]byte(`[]`), ""); err == nil {
+		t.Fatalf("empty inspect output should return error")
+	}
+}
+
+func TestInspectBindUnderPWD(t *testing.T) {
+	data := []byte(`[{"Name": "/web", "Config": {"Image": "nginx"}, "Mounts": [
+		{"Type": "bind", "Source": "/home/a/site", "Destination": "/usr/share/nginx/html", "RW": true},
+		{"Type": "bind", "Source": "/home/a2/conf", "Destination": "/etc/nginx/conf.d", "RW": true},
+		{"Type": "bind", "Source": "/home/a", "Destination": "/work", "RW": true}]}]`)
+	live, err := NewMockContainerFromInspect(data, "/home/a/")
+	if err != nil {
+		t.Fatalf("load inspect fail: %v", err)
+	}
+	if live.Volume["$PWD/site"] != "/usr/share/nginx/html" || live.Volume["/home/a2/conf"] != "/etc/nginx/conf.d" || live.Volume["$PWD"] != "/work" {
+		t.Fatalf("worng volumes: %v", live.Volume)
+	}
+}
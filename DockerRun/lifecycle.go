@@ -0,0 +1,475 @@
+package DockerRun
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+//LifecycleFlags is all flags allowled to used in the commands that manage containers
+var LifecycleFlags = map[string]FlagSet{
+	"ps": {
+		Short: []string{"a", "q", "f", "n", "l", "s"},
+		Long:  []string{"all", "quiet", "filter", "format", "last", "latest", "size", "no-trunc"},
+		NoArg: []string{"a", "all", "q", "quiet", "l", "latest", "s", "size", "no-trunc"},
+	},
+	"stop":    {Short: []string{"t", "s"}, Long: []string{"time", "timeout", "signal"}},
+	"restart": {Short: []string{"t", "s"}, Long: []string{"time", "timeout", "signal"}},
+	"kill":    {Short: []string{"s"}, Long: []string{"signal"}},
+	"start": {
+		Short: []string{"a", "i"},
+		Long:  []string{"attach", "interactive"},
+		NoArg: []string{"a", "attach", "i", "interactive"},
+	},
+	"rm": {
+		Short: []string{"f", "v", "l"},
+		Long:  []string{"force", "volumes", "link"},
+		NoArg: []string{"f", "force", "v", "volumes", "l", "link"},
+	},
+	"logs": {
+		Short: []string{"f", "n", "t"},
+		Long:  []string{"follow", "tail", "since", "until", "timestamps", "details"},
+		NoArg: []string{"f", "follow", "t", "timestamps", "details"},
+	},
+	"inspect": {
+		Short: []string{"f", "s"},
+		Long:  []string{"format", "size", "type"},
+		NoArg: []string{"s", "size"},
+	},
+}
+
+//the model to simulate the property of a command that manage containers: ps, stop, start, restart, kill, rm, logs, inspect
+type MockLifecycle struct {
+	Action     string
+	Targets    []string
+	Filters    []string
+	Format     string
+	Timeout    string
+	Signal     string
+	Tail       string
+	Since      string
+	Until      string
+	IsAll      bool
+	IsQuiet    bool
+	IsForce    bool
+	IsVolumes  bool
+	IsFollow   bool
+	IsAttach   bool
+	Timestamps bool
+	Client     ClientConfig //the global options before the subcommand
+}
+
+//create an MockLifecycle according to a docker ps/stop/start/restart/kill/rm/logs/inspect command,
+//return error if it command have a worng syntax
+func NewMockLifecycle(dockerCmd string) (model MockLifecycle, err error) {
+	cmdArray := splitCommand(dockerCmd)
+	if reason := model.basicCheck(cmdArray); !reason.IsEmpty() {
+		return model, reason
+	}
+	return model, nil
+}
+
+//check the basic syntax of a command that manage containers,
+//return the fall reason or return a empty string if the command is accpeted
+//synatax: docker ps [OPTIONS]
+//         docker stop|start|restart|kill|rm|inspect [OPTIONS] CONTAINER [CONTAINER...]
+//         docker logs [OPTIONS] CONTAINER
+func (this *MockLifecycle) BasicCheck(cmd []string) string {
+	return this.basicCheck(cmd).String()
+}
+
+//check the basic syntax of a command that manage containers, return the fall reason or a empty message if the command is accpeted
+func (this *MockLifecycle) basicCheck(cmd []string) Message {
+	if len(cmd) == 0 {
+		return msg("run.empty")
+	}
+	if len(cmd) < 2 {
+		return msg("run.too_short")
+	}
+	if cmd[0] != "docker" {
+		return msg("run.not_docker")
+	}
+	cmd, reason := stripGlobalOptions(cmd, &this.Client)
+	if !reason.IsEmpty() {
+		return reason
+	}
+	cmd = normalizeCommand(cmd)
+	flagSet, have := LifecycleFlags[cmd[1]]
+	if !have {
+		return msg("command.not_kind", "container management")
+	}
+	this.Action = cmd[1]
+	nowAt, reason := parseOptions(cmd, 2, flagSet, this)
+	if !reason.IsEmpty() {
+		return reason
+	}
+	for _, target := range cmd[nowAt:] {
+		target = trimStr(target)
+		if !isContainerName(target) {
+			return msg("arg.name", target)
+		}
+		this.Targets = append(this.Targets, target)
+	}
+	switch {
+	case this.Action == "ps" && len(this.Targets) > 0:
+		return msg("command.no_arg_got", "ps", this.Targets[0])
+	case this.Action == "logs" && len(this.Targets) != 1:
+		return msg("command.exactly_one", "logs")
+	case this.Action != "ps" && len(this.Targets) == 0:
+		return msg("command.at_least_one", this.Action)
+	}
+	return Message{}
+}
+
+//Setting up the property according to the flag and argument
+//if the format of arguments not right it will return error
+func (this *MockLifecycle) HandleArgument(flag, arg string) error {
+	arg = trimStr(arg)
+	switch flag {
+	case "f", "filter", "format":
+		if this.Action == "ps" && (flag == "f" || flag == "filter") {
+			if !strings.Contains(arg, "=") {
+				return msg("arg.filter")
+			}
+			this.Filters = append(this.Filters, arg)
+		} else {
+			this.Format = arg
+		}
+	case "t", "time", "timeout":
+		if _, err := strconv.Atoi(arg); err != nil {
+			return msg("arg.number", flag, arg)
+		}
+		this.Timeout = arg
+	case "s", "signal":
+		if arg == "" {
+			return msg("arg.signal", arg)
+		}
+		this.Signal = normalizeSignal(arg)
+	case "n", "tail", "last":
+		if _, err := strconv.Atoi(arg); err != nil && arg != "all" {
+			return msg("arg.number", flag, arg)
+		}
+		this.Tail = arg
+	case "since":
+		this.Since = arg
+	case "until":
+		this.Until = arg
+	case "type":
+		if arg != "container" {
+			return msg("arg.inspect_type", arg)
+		}
+	default:
+		return msg("flag.invalid", flag)
+	}
+	return nil
+}
+
+//Setting up the property according to the flag that without argument
+//return error only if the flag is not exist
+func (this *MockLifecycle) HandleFlag(flag string) error {
+	switch flag {
+	case "a", "all", "attach":
+		if this.Action == "start" {
+			this.IsAttach = true
+		} else {
+			this.IsAll = true
+		}
+	case "q", "quiet":
+		this.IsQuiet = true
+	case "f", "force", "follow":
+		if this.Action == "logs" {
+			this.IsFollow = true
+		} else {
+			this.IsForce = true
+		}
+	case "v", "volumes":
+		this.IsVolumes = true
+	case "t", "timestamps":
+		this.Timestamps = true
+	case "i", "interactive", "l", "latest", "link", "s", "size", "no-trunc", "details":
+	default:
+		return msg("flag.invalid_short", flag)
+	}
+	return nil
+}
+
+//judge if the property of a command that manage containers is right by compared to the answer
+//return a string to describe the mistake or a null string if it command is accepted
+func JudgeLifecycle(test, ans *MockLifecycle) string {
+	return judgeLifecycle(test, ans).String()
+}
+
+//judge the property of a command that manage containers, return the mistake or a empty message if it command is accepted
+func judgeLifecycle(test, ans *MockLifecycle) Message {
+	if test == nil {
+		return msg("judge.null_test")
+	}
+	if ans == nil {
+		return msg("judge.null_ans")
+	}
+	if test.Action != ans.Action {
+		return msg("judge.action", ans.Action, test.Action)
+	}
+	for _, target := range ans.Targets {
+		if !findInArray(test.Targets, target) {
+			return msg("judge.container_missing", target)
+		}
+	}
+	for _, target := range test.Targets {
+		if !findInArray(ans.Targets, target) {
+			return msg("judge.unexpect_container", target)
+		}
+	}
+	for _, filter := range ans.Filters {
+		if !findInArray(test.Filters, filter) {
+			return msg("judge.filter", filter)
+		}
+	}
+	checks := []struct {
+		name      string
+		ans, test bool
+	}{
+		{"-a or --all", ans.IsAll, test.IsAll},
+		{"-q or --quiet", ans.IsQuiet, test.IsQuiet},
+		{"-f or --force", ans.IsForce, test.IsForce},
+		{"-v or --volumes", ans.IsVolumes, test.IsVolumes},
+		{"-f or --follow", ans.IsFollow, test.IsFollow},
+		{"-a or --attach", ans.IsAttach, test.IsAttach},
+		{"-t or --timestamps", ans.Timestamps, test.Timestamps},
+	}
+	for _, c := range checks {
+		if c.ans && !c.test {
+			return msg("judge.flag", c.name)
+		}
+	}
+	values := []struct {
+		id        string
+		ans, test string
+	}{
+		{"judge.format", ans.Format, test.Format},
+		{"judge.timeout", ans.Timeout, test.Timeout},
+		{"judge.signal", ans.Signal, test.Signal},
+		{"judge.tail", ans.Tail, test.Tail},
+		{"judge.since", ans.Since, test.Since},
+		{"judge.until", ans.Until, test.Until},
+	}
+	for _, v := range values {
+		if v.ans != "" && v.test != v.ans {
+			return msg(v.id, v.ans, v.test)
+		}
+	}
+	if reason := judgeClient(&test.Client, &ans.Client); !reason.IsEmpty() {
+		return reason
+	}
+	return Message{}
+}
+
+//apply a command that manage containers to the engine, return the output of the command,
+//the error is the same as docker daemon if the container not found or can not be changed
+func (this *MockEngine) Manage(m *MockLifecycle) (string, error) {
+	if m.Action == "ps" {
+		return this.ps(m)
+	}
+	output := ""
+	inspects := []InspectContainer{}
+	for _, target := range m.Targets {
+		c := this.Container(target)
+		if c == nil {
+			return output, fmt.Errorf("No such container: %s", target)
+		}
+		switch m.Action {
+		case "stop", "kill":
+			if m.Action == "kill" && c.State != StateRunning {
+				return output, fmt.Errorf("Cannot kill container: %s: Container %s is not running", target, c.ID)
+			}
+			this.exit(c)
+			output += target + "\n"
+		case "start", "restart":
+			this.stop(c)
+			if err := this.start(c); err != nil {
+				return output, err
+			}
+			output += target + "\n"
+		case "rm":
+			if c.State == StateRunning && !m.IsForce {
+				return output, fmt.Errorf("You cannot remove a running container %s. Stop the container before attempting removal or force remove", c.ID)
+			}
+			this.remove(c)
+			output += target + "\n"
+		case "logs":
+			output += tailLines(c.Logs, m.Tail)
+		case "inspect":
+			inspects = append(inspects, c.Inspect())
+		}
+	}
+	if m.Action == "inspect" {
+		if m.Format != "" {
+			for _, i := range inspects {
+				out, err := executeFormat(m.Format, i)
+				if err != nil {
+					return output, err
+				}
+				output += out + "\n"
+			}
+			return output, nil
+		}
+		data, err := json.MarshalIndent(inspects, "", "    ")
+		return string(data) + "\n", err
+	}
+	return output, nil
+}
+
+//list the containers like 'docker ps', the filters name, status, ancestor and id are supported
+func (this *MockEngine) ps(m *MockLifecycle) (string, error) {
+	output := ""
+	if !m.IsQuiet && m.Format == "" {
+		output = fmt.Sprintf("%-12s   %-20s   %-20s   %-10s   %s\n", "CONTAINER ID", "IMAGE", "COMMAND", "STATUS", "NAMES")
+	}
+	for i := len(this.Containers) - 1; i >= 0; i-- { //the latest one is listed first
+		c := this.Containers[i]
+		if !m.IsAll && c.State != StateRunning {
+			continue
+		}
+		matched := true
+		for _, f := range m.Filters {
+			kv := strings.SplitN(f, "=", 2)
+			switch kv[0] {
+			case "name":
+				matched = matched && strings.Contains(c.Name, kv[1])
+			case "status":
+				matched = matched && c.State == kv[1]
+			case "ancestor":
+				matched = matched && (c.Config.Images == kv[1] || c.Config.Images == withDefaultTag(kv[1]))
+			case "id":
+				matched = matched && strings.HasPrefix(c.ID, kv[1])
+			default:
+				return "", fmt.Errorf("invalid filter '%s'", kv[0])
+			}
+		}
+		if !matched {
+			continue
+		}
+		command := strings.TrimSpace(c.Config.Command + " " + strings.Join(c.Config.Arg, " "))
+		switch {
+		case m.IsQuiet:
+			output += c.ID[:12] + "\n"
+		case m.Format != "":
+			row := map[string]string{"ID": c.ID[:12], "Names": c.Name, "Image": c.Config.Images, "Command": command, "Status": c.State}
+			out, err := executeFormat(m.Format, row)
+			if err != nil {
+				return "", err
+			}
+			output += out + "\n"
+		default:
+			output += fmt.Sprintf("%-12s   %-20s   %-20s   %-10s   %s\n", c.ID[:12], c.Config.Images, "\""+command+"\"", c.State, c.Name)
+		}
+	}
+	return output, nil
+}
+
+//return the docker inspect output of a container
+func (this *EngineContainer) Inspect() InspectContainer {
+	config := this.Config
+	body := config.ToEngineCreate("")
+	inspect := InspectContainer{
+		Id:   this.ID,
+		Name: "/" + this.Name,
+		State: InspectState{
+			Status:   this.State,
+			Running:  this.State == StateRunning,
+			ExitCode: this.ExitCode,
+		},
+		Config: InspectConfig{
+			Hostname:     config.HostName,
+			User:         body.User,
+			AttachStdin:  body.AttachStdin,
+			AttachStdout: body.AttachStdout,
+			AttachStderr: body.AttachStderr,
+			Tty:          body.Tty,
+			OpenStdin:    body.OpenStdin,
+			Env:          body.Env,
+			Cmd:          body.Cmd,
+			Image:        body.Image,
+			WorkingDir:   body.WorkingDir,
+			Labels:       body.Labels,
+		},
+		HostConfig: InspectHostConfig{
+			Binds:           body.HostConfig.Binds,
+			NetworkMode:     body.HostConfig.NetworkMode,
+			RestartPolicy:   body.HostConfig.RestartPolicy,
+			PortBindings:    body.HostConfig.PortBindings,
+			AutoRemove:      body.HostConfig.AutoRemove,
+			Links:           body.HostConfig.Links,
+			PublishAllPorts: body.HostConfig.PublishAllPorts,
+			Memory:          body.HostConfig.Memory,
+			CpuShares:       config.CpuShare,
+			Privileged:      body.HostConfig.Privileged,
+			PidMode:         body.HostConfig.PidMode,
+			CapAdd:          body.HostConfig.CapAdd,
+			CapDrop:         body.HostConfig.CapDrop,
+			Devices:         body.HostConfig.Devices,
+		},
+	}
+	if inspect.Config.Hostname == "" {
+		inspect.Config.Hostname = this.ID[:12]
+	}
+	if inspect.HostConfig.NetworkMode == "" {
+		inspect.HostConfig.NetworkMode = "default"
+	}
+	for _, source := range sortedKeys(config.Volume) {
+		m := InspectMount{Type: "bind", Source: source, Destination: config.Volume[source], Mode: config.VolumeOption[source]}
+		m.RW = !findInArray(strings.Split(m.Mode, ","), "ro")
+		if isNamedVolume(source) {
+			m.Type, m.Name, m.Source = "volume", source, "/var/lib/docker/volumes/"+source+"/_data"
+		}
+		inspect.Mounts = append(inspect.Mounts, m)
+	}
+	return inspect
+}
+
+//execute a go template given by --format, such as '{{.State.Status}}' or 'table {{.Names}}'
+func executeFormat(format string, data interface{}) (string, error) {
+	format = strings.TrimPrefix(format, "table ")
+	t, err := template.New("format").Funcs(template.FuncMap{
+		"json": func(v interface{}) string {
+			b, _ := json.Marshal(v)
+			return string(b)
+		},
+	}).Parse(format)
+	if err != nil {
+		return "", fmt.Errorf("template parsing error: %v", err)
+	}
+	var sb strings.Builder
+	if err = t.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("template: %v", err)
+	}
+	return sb.String(), nil
+}
+
+//return the last n lines of logs, n can be 'all'
+func tailLines(logs, n string) string {
+	count, err := strconv.Atoi(n)
+	if err != nil || count < 0 {
+		return logs
+	}
+	lines := strings.SplitAfter(logs, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if count < len(lines) {
+		lines = lines[len(lines)-count:]
+	}
+	return strings.Join(lines, "")
+}
+
+//change a signal into its name without SIG, such as SIGKILL, kill and 9 are the same
+func normalizeSignal(signal string) string {
+	numbers := map[string]string{"1": "HUP", "2": "INT", "3": "QUIT", "9": "KILL", "15": "TERM"}
+	signal = strings.TrimPrefix(strings.ToUpper(signal), "SIG")
+	if name, have := numbers[signal]; have {
+		return name
+	}
+	return signal
+}
@@ -0,0 +1,101 @@
+package DockerRun
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMockLifecycle(t *testing.T) {
+	cases := map[string][]string{
+		`docker ps -a`:                        {`docker ps --all`, `docker ps -aq`, `docker ps --all=true --format "{{.Names}}"`},
+		`docker stop -t 5 server1 server2`:    {`docker stop --time=5 server2 server1`, `docker stop --timeout 5 server1 server2`},
+		`docker kill -s SIGKILL server1`:      {`docker kill --signal=9 server1`, `docker kill -s kill server1`},
+		`docker rm -f server1`:                {`docker rm --force server1`, `docker rm -fv server1`},
+		`docker logs -f --tail 10 server1`:    {`docker logs --follow -n 10 server1`},
+		`docker ps --filter status=exited`:    {`docker ps -a -f status=exited`, `docker ps -f "status=exited"`},
+		`docker inspect -f {{.Name}} server1`: {`docker inspect --format={{.Name}} server1`},
+	}
+	for standard, challenger := range cases {
+		ans, err := NewMockLifecycle(standard)
+		if err != nil {
+			t.Fatalf("Standard command %s fall: %v", standard, err)
+		}
+		for _, cmd := range challenger {
+			test, err := NewMockLifecycle(cmd)
+			if err != nil {
+				t.Fatalf("Create lifecycle fail at %s : %v", cmd, err)
+			}
+			if res := JudgeLifecycle(&test, &ans); res != "" {
+				t.Fatalf("Unpass at %s : %v", cmd, res)
+			}
+		}
+	}
+	failed := map[string]string{
+		`docker rm server1`:               `docker rm -f server1`,
+		`docker stop server1`:             `docker stop server1 server2`,
+		`docker start server1`:            `docker restart server1`,
+		`docker logs --tail 5 server1`:    `docker logs --tail 10 server1`,
+		`docker logs --since 10m server1`: `docker logs --until 10m server1`,
+		`docker logs --until 10m server1`: `docker logs --since 10m server1`,
+	}
+	for cmd, standard := range failed {
+		test, _ := NewMockLifecycle(cmd)
+		ans, _ := NewMockLifecycle(standard)
+		if JudgeLifecycle(&test, &ans) == "" {
+			t.Fatalf("command %s should not pass %s", cmd, standard)
+		}
+	}
+	for _, cmd := range []string{`docker ps server1`, `docker stop`, `docker logs a1 a2`, `docker stop -t x server1`, `docker ps -f status`} {
+		if _, err := NewMockLifecycle(cmd); err == nil {
+			t.Fatalf("worng command %s pass!", cmd)
+		}
+	}
+}
+
+func TestMockEngineManage(t *testing.T) {
+	engine := NewMockEngine()
+	engine.RunCommands([]string{
+		`docker run -d -p 8080:80 --name server1 alpine`,
+		`docker run --name server2 alpine echo hello`,
+	})
+	steps := []struct {
+		cmd, output, err string
+	}{
+		{`docker ps -q`, engine.Container("server1").ID[:12] + "\n", ``},
+		{`docker ps -a --format {{.Names}}:{{.Status}}`, "server2:exited\nserver1:running\n", ``},
+		{`docker logs server2`, "hello\n", ``},
+		{`docker rm server1`, ``, `You cannot remove a running container`},
+		{`docker kill server2`, ``, `is not running`},
+		{`docker stop server1`, "server1\n", ``},
+		{`docker inspect -f {{.State.Status}} server1`, "exited\n", ``},
+		{`docker run -d -p 8080:80 --name server3 alpine`, ``, ``},
+		{`docker start server1`, ``, `port is already allocated`},
+		{`docker rm server1 server2`, "server1\nserver2\n", ``},
+		{`docker logs server2`, ``, `No such container: server2`},
+	}
+	for _, step := range steps {
+		if strings.HasPrefix(step.cmd, "docker run") {
+			engine.RunCommands([]string{step.cmd})
+			continue
+		}
+		m, err := NewMockLifecycle(step.cmd)
+		if err != nil {
+			t.Fatalf("Create lifecycle fail at %s : %v", step.cmd, err)
+		}
+		output, err := engine.Manage(&m)
+		if step.err != "" {
+			if err == nil || !strings.Contains(err.Error(), step.err) {
+				t.Fatalf("command '%s' expect error '%s' but got: %v", step.cmd, step.err, err)
+			}
+		} else if err != nil || output != step.output {
+			t.Fatalf("command '%s' expect output %q but got %q, %v", step.cmd, step.output, output, err)
+		}
+	}
+	ans, _ := NewMockContainer(`docker run -d -p 8080:80 --name server3 alpine`)
+	inspect, _ := NewMockLifecycle(`docker inspect server3`)
+	output, _ := engine.Manage(&inspect)
+	live, err := NewMockContainerFromInspect([]byte(output), "")
+	if err != nil || Judge(&live, &ans) != "" {
+		t.Fatalf("inspect output should be loaded as the same container: %v\n%s", err, output)
+	}
+}
@@ -0,0 +1,124 @@
+package DockerRun
+
+import (
+	"fmt"
+	"strings"
+)
+
+//the severity of a lint issue
+const (
+	SeverityInfo    = "info"
+	SeverityWarning = "warning"
+)
+
+//LintRule is a best practice of docker run, check return the message if the container break it,
+//the explanation is the message lint.<ID> in the catalog
+type LintRule struct {
+	ID       string
+	Severity string
+	check    func(ctr *MockContainer) []Message
+}
+
+//LintIssue is a best practice that the container break
+type LintIssue struct {
+	RuleID      string
+	Severity    string
+	Message     string
+	Explanation string
+	message     Message
+}
+
+//return the issue in the form of [BP001] warning: message
+func (this LintIssue) String() string {
+	return fmt.Sprintf("[%s] %s: %s", this.RuleID, this.Severity, this.Message)
+}
+
+//return the issue with the message and explanation in the locale
+func (this LintIssue) localize(catalog *Catalog, locale string) LintIssue {
+	this.Message = catalog.Render(locale, this.message)
+	this.Explanation = catalog.Message(locale, "lint."+this.RuleID)
+	return this
+}
+
+//LintRules is the best practices checked by Lint()
+var LintRules = []LintRule{
+	{
+		ID: "BP001", Severity: SeverityWarning,
+		check: func(ctr *MockContainer) []Message {
+			if !ctr.IsTagged {
+				return []Message{msg("lint.no_tag", ctr.Images)}
+			}
+			if strings.HasSuffix(ctr.Images, ":latest") {
+				return []Message{msg("lint.latest_tag", ctr.Images)}
+			}
+			return nil
+		},
+	},
+	{
+		ID: "BP002", Severity: SeverityInfo,
+		check: func(ctr *MockContainer) []Message {
+			if ctr.Memory == 0 && ctr.IsDetach {
+				return []Message{msg("lint.no_memory")}
+			}
+			return nil
+		},
+	},
+	{
+		ID: "BP003", Severity: SeverityInfo,
+		check: func(ctr *MockContainer) []Message {
+			if ctr.User == "" {
+				return []Message{msg("lint.no_user")}
+			}
+			if findInArray([]string{"root", "0", "root:root", "0:0"}, ctr.User) {
+				return []Message{msg("lint.root")}
+			}
+			return nil
+		},
+	},
+	{
+		ID: "BP004", Severity: SeverityInfo,
+		check: func(ctr *MockContainer) []Message {
+			if !ctr.IsDetach && !ctr.IsCreate && !ctr.IsRemove && ctr.Restart == "" && !ctr.isCompose {
+				return []Message{msg("lint.no_rm")}
+			}
+			return nil
+		},
+	},
+	{
+		ID: "BP005", Severity: SeverityInfo,
+		check: func(ctr *MockContainer) []Message {
+			messages := []Message{}
+			for _, source := range sortedKeys(ctr.Volume) {
+				if !isNamedVolume(source) {
+					messages = append(messages, msg("lint.bind_mount", source, ctr.Volume[source]))
+				}
+			}
+			return messages
+		},
+	},
+	{
+		ID: "BP006", Severity: SeverityWarning,
+		check: func(ctr *MockContainer) []Message {
+			if ctr.IsDetach && !ctr.IsRemove && ctr.Restart == "" {
+				return []Message{msg("lint.no_restart")}
+			}
+			return nil
+		},
+	},
+}
+
+//check the container by the best practices, the rules in suppress are skipped
+//return the issues in the order of rules
+func Lint(ctr *MockContainer, suppress []string) []LintIssue {
+	issues := []LintIssue{}
+	for _, rule := range LintRules {
+		if findInArray(suppress, rule.ID) {
+			continue
+		}
+		for _, message := range rule.check(ctr) {
+			issue := LintIssue{RuleID: rule.ID, Severity: rule.Severity, message: message}
+			issues = append(issues, issue.localize(defaultCatalog, LocaleEnglish))
+		}
+	}
+	return issues
+}
@@ -0,0 +1,61 @@
+package DockerRun
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	expect := map[string]string{
+		`docker run -d -m 512m -u app --restart always -v data:/data nginx:1.25`:    ``,
+		`docker run -d -m 512m -u app --restart always nginx`:                       `BP001`,
+		`docker run -d -m 512m -u app --restart always nginx:latest`:                `BP001`,
+		`docker run -d -u app --restart always nginx:stable`:                        `BP002`,
+		`docker run -d -m 512m -u root --restart always nginx:stable`:               `BP003`,
+		`docker run -m 512m -u app alpine:edge ls`:                                  `BP004`,
+		`docker run --rm -m 512m -u app -v $PWD:/src alpine:edge ls`:                `BP005`,
+		`docker run -d -m 512m -u app nginx:stable`:                                 `BP006`,
+		`docker run -d -m 512m --user 1000:1000 --rm -v /srv/html:/html nginx:1.25`: `BP005`,
+	}
+	for cmd, id := range expect {
+		ctr, err := NewMockContainer(cmd)
+		if err != nil {
+			t.Fatalf("Create container fail at command %s : %v", cmd, err)
+		}
+		issues := Lint(&ctr, nil)
+		if (id == "" && len(issues) != 0) || (id != "" && (len(issues) != 1 || issues[0].RuleID != id)) {
+			t.Fatalf("command '%s' expect issue '%s' but got: %v", cmd, id, issues)
+		}
+	}
+	compose := `
+services:
+  web:
+    image: nginx:1.25
+    mem_limit: 512m
+    user: app
+    restart: always
+  job:
+    image: alpine
+    mem_limit: 512m
+    user: app
+    command: ls
+`
+	for service, id := range map[string]string{"web": "", "job": "BP001"} {
+		ctr, err := NewMockContainerFromCompose(compose, service)
+		if err != nil {
+			t.Fatalf("Create container fail at service %s : %v", service, err)
+		}
+		issues := Lint(&ctr, nil)
+		if (id == "" && len(issues) != 0) || (id != "" && (len(issues) != 1 || issues[0].RuleID != id)) {
+			t.Fatalf("service '%s' expect issue '%s' but got: %v", service, id, issues)
+		}
+	}
+	exercise := Exercise{Answer: `docker run -d --name web nginx`, Suppress: []string{"BP002", "BP003"}}
+	result := exercise.Check(`docker run -d --name web nginx`)
+	if result.Reason != "" || len(result.Lint) != 2 || result.Lint[0].RuleID != "BP001" || result.Lint[1].RuleID != "BP006" {
+		t.Fatalf("unexpect result: %+v", result)
+	}
+	if !strings.HasPrefix(result.Lint[0].String(), "[BP001] warning: images nginx:latest have no tag") {
+		t.Fatalf("unexpect issue: %s", result.Lint[0])
+	}
+}
@@ -0,0 +1,440 @@
+package DockerRun
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+//the locales that have a built-in translation
+const (
+	LocaleEnglish = "en"
+	LocaleChinese = "zh-CN"
+)
+
+//MessageDef is a feedback message in the catalog, English is the format such as 'Images name %s not legal!',
+//the parameters of a translation are written as {1}, {2} so that they can be reordered
+type MessageDef struct {
+	ID      string
+	English string
+	Chinese string
+}
+
+//Messages is the feedback messages of the docker commands, from parsing and judging to the hints of an exercise
+var Messages = []MessageDef{
+	{"text", "%s", "{1}"}, //a message that is not in the catalog, such as the error output by the engine
+	//syntax of docker run command
+	{"run.empty", "Receive empty command!", "命令为空！"},
+	{"run.too_short", "Requires at least two element!", "命令至少需要两个部分！"},
+	{"run.not_docker", "Not a docker command!", "这不是一个 docker 命令！"},
+	{"run.not_run", "Not a run command!", "这不是一个 run 命令！"},
+	{"run.create_detach", "unknown flag: --detach, docker create never start the container", "未知参数：--detach，docker create 不会启动容器"},
+	{"run.no_image", "Can't find images name from given command!", "命令中找不到镜像名！"},
+	{"run.bad_image", "Images name %s not legal!", "镜像名 {1} 不合法！"},
+	{"flag.unexpect", "Unexpect flag: %s", "参数格式错误：{1}"},
+	{"flag.unknown", "Unknown flag: %s", "未知参数：{1}"},
+	{"flag.unexpect_arg", "Unexpect flag and argument: %s=%s", "参数 {1} 不接受值 {2}"},
+	{"flag.no_arg_long", "Not enough of argument after %s", "{1} 后面缺少参数值"},
+	{"flag.unknown_short", "unknown shorthand flag: %s in %s ", "未知短参数：{2} 中的 {1} "},
+	{"flag.unexpect_short_arg", "Unexpect argument: %s=%s", "参数 {1} 不接受值 {2}"},
+	{"flag.no_arg_short", "Not enough of argument after -%s", "-{1} 后面缺少参数值"},
+	{"flag.invalid", "Invalid flag: --%s", "无效参数：--{1}"},
+	{"flag.invalid_short", "unknown shorthand flag: '%s'", "未知短参数：'{1}'"},
+	{"flag.invalid_name", "unknown flag: '%s'", "未知参数：'{1}'"},
+	{"shell.quote", "unexpected EOF while looking for matching %s", "找不到与 {1} 匹配的引号"},
+	{"dialect.unknown", "Unknown dialect: %s", "未知的命令行方言：{1}"},
+	{"shell.syntax", "syntax error near unexpected token `%s'", "语法错误：意外的符号 `{1}'"},
+	{"shell.eof", "syntax error: unexpected end of file after `%s'", "语法错误：`{1}' 之后意外结束"},
+	{"shell.pipe", "pipe is not supported: %s|", "不支持管道：{1}|"},
+	//syntax of the other docker commands
+	{"command.unknown", "unknown command: %s", "未知的命令：{1}"},
+	{"command.kind", "Command not right, expect a %s command but got: %s", "命令不正确，应为 {1} 命令，实际为：{2}"},
+	{"command.not_kind", "Not a %s command!", "这不是一个 {1} 命令！"},
+	{"command.too_short", "Requires at least three element!", "命令至少需要三个部分！"},
+	{"command.unknown_action", "Unknown %s command: %s", "未知的 {1} 命令：{2}"},
+	{"command.exactly_one", "\"docker %s\" requires exactly 1 argument.", "\"docker {1}\" 需要且只需要 1 个参数。"},
+	{"command.exactly_two", "\"docker %s\" requires exactly 2 arguments.", "\"docker {1}\" 需要且只需要 2 个参数。"},
+	{"command.at_least_one", "\"docker %s\" requires at least 1 argument.", "\"docker {1}\" 至少需要 1 个参数。"},
+	{"command.at_least_two", "\"docker %s\" requires at least 2 arguments.", "\"docker {1}\" 至少需要 2 个参数。"},
+	{"command.at_most_one", "\"docker %s\" requires at most 1 argument.", "\"docker {1}\" 最多只能有 1 个参数。"},
+	{"command.no_arg", "\"docker %s\" accepts no arguments.", "\"docker {1}\" 不接受参数。"},
+	{"command.no_arg_got", "\"docker %s\" accepts no arguments, but got: %s", "\"docker {1}\" 不接受参数，实际为：{2}"},
+	{"command.extra_arg", "\"docker %s\" requires exactly 1 argument, but got extra argument: %s", "\"docker {1}\" 需要且只需要 1 个参数，多余的参数：{2}"},
+	//global options of docker client
+	{"client.no_subcommand", "Can't find subcommand from given command!", "命令中找不到子命令！"},
+	{"client.host_context", "conflicting options: either specify --host or --context, not both", "参数冲突：--host 和 --context 只能指定一个"},
+	{"client.host", "Invalid bind address format: %s", "无效的守护进程地址格式：{1}"},
+	{"client.context", "context name \"%s\" is invalid", "上下文名称 \"{1}\" 无效"},
+	{"client.log_level", "Unable to parse logging level: %s", "无法解析日志级别：{1}"},
+	//arguments of the flags
+	{"arg.publish", "invalid publish opts format (should be port1:port2 but got '%s').", "端口映射格式错误（应为 端口1:端口2，实际为 '{1}'）。"},
+	{"arg.port_allocated", "Port is already allocated: %s", "端口已被占用：{1}"},
+	{"arg.number", "%s need a number, but got: %s", "{1} 需要一个数字，实际为：{2}"},
+	{"arg.cpu_shares", "The allowed cpu-shares is from 2 to 262144", "cpu-shares 的取值范围是 2 到 262144"},
+	{"arg.volume", "Invalid volume argument: %s", "无效的数据卷参数：{1}"},
+	{"arg.volume_mode", "invalid mode for %s: %s", "{1} 不支持的数据卷选项：{2}"},
+	{"arg.mount_point", "Duplicate mount point: %s", "重复的挂载点：{1}"},
+	{"arg.name", "Invalid container name (%s), only [a-zA-Z0-9][a-zA-Z0-9_.-] are allowed.", "无效的容器名（{1}），只允许使用 [a-zA-Z0-9][a-zA-Z0-9_.-]。"},
+	{"arg.userns", "invalid userns mode for %s: %s", "{1} 不支持的 userns 模式：{2}"},
+	{"arg.platform", "invalid platform: %s", "无效的平台：{1}"},
+	{"arg.pull", "invalid pull option: '%s': must be one of \"always\", \"missing\" or \"never\"", "无效的 pull 选项：'{1}'，只能是 \"always\"、\"missing\" 或 \"never\""},
+	{"arg.gpus", "invalid gpus argument: %s", "无效的 gpus 参数：{1}"},
+	{"arg.mount", "invalid argument \"%s\" for \"--mount\" flag: %v", "--mount 的参数 \"{1}\" 无效：{2}"},
+	{"arg.memory", "Invalid memory argument: %s", "无效的内存参数：{1}"},
+	{"arg.pid", "--pid: invalid PID mode", "--pid：无效的 PID 模式"},
+	{"arg.capability", "invalid capability: %s", "无效的 capability：{1}"},
+	{"arg.device", "invalid device mode: %s", "无效的设备权限：{1}"},
+	{"arg.restart", "invalid restart policy: %s", "无效的重启策略：{1}"},
+	{"arg.workdir", "Invali workdir: %s", "无效的工作目录：{1}"},
+	{"arg.env", "invalid environment variable: %s", "无效的环境变量：{1}"},
+	{"arg.attach", "Invalid argument '%s' for -a, --attach", "-a, --attach 的参数 '{1}' 无效"},
+	{"arg.object_name", "Invalid name (%s), only [a-zA-Z0-9][a-zA-Z0-9_.-] are allowed.", "无效的名称（{1}），只允许使用 [a-zA-Z0-9][a-zA-Z0-9_.-]。"},
+	{"arg.volume_name", "Invalid volume name (%s), only [a-zA-Z0-9][a-zA-Z0-9_.-] are allowed.", "无效的数据卷名（{1}），只允许使用 [a-zA-Z0-9][a-zA-Z0-9_.-]。"},
+	{"arg.volume_name_conflict", "conflicting options: either specify --name or provide positional arg, not both", "参数冲突：--name 和数据卷名只能指定一个"},
+	{"arg.volume_driver", "Invalid driver: %s", "无效的驱动：{1}"},
+	{"arg.key_value", "invalid key/value pair format: %s", "无效的键值对格式：{1}"},
+	{"arg.reference", "invalid reference format: %s", "无效的镜像引用格式：{1}"},
+	{"arg.tag", "invalid argument \"%s\" for \"-t, --tag\" flag: invalid reference format", "-t, --tag 的参数 \"{1}\" 无效：镜像引用格式错误"},
+	{"arg.dockerfile", "Invalid dockerfile argument: %s", "无效的 Dockerfile 参数：{1}"},
+	{"arg.build_arg", "invalid build argument: %s", "无效的构建参数：{1}"},
+	{"arg.filter", "bad format of filter (expected name=value)", "过滤条件格式错误（应为 name=value）"},
+	{"arg.signal", "Invalid signal: %s", "无效的信号：{1}"},
+	{"arg.inspect_type", "only container can be inspected, but got: %s", "只能查看容器，实际为：{1}"},
+	{"arg.network_driver", "plugin \"%s\" not found", "找不到插件 \"{1}\""},
+	{"arg.cidr", "invalid CIDR address: %s", "无效的 CIDR 地址：{1}"},
+	{"arg.ip", "invalid IP address: %s", "无效的 IP 地址：{1}"},
+	{"arg.driver_opt", "invalid key/value pair format in driver options: %s", "驱动选项的键值对格式错误：{1}"},
+	{"arg.gateway", "no matching subnet for gateway %s", "网关 {1} 没有匹配的子网"},
+	//docker version
+	{"version.invalid", "Invalid docker version: %s", "无效的 docker 版本：{1}"},
+	{"version.since", "%s requires docker %s or later, but the target is docker %s", "{1} 需要 docker {2} 或更高版本，但目标版本是 docker {3}"},
+	{"version.removed", "%s is removed since docker %s", "{1} 从 docker {2} 起已被移除"},
+	{"version.deprecated", "%s is deprecated since docker %s", "{1} 从 docker {2} 起已被弃用"},
+	{"version.legacy", "%s is a legacy feature, %s", "{1} 是遗留功能，{2}"},
+	{"version.use_network", "use a user-defined network instead", "请改用自定义网络"},
+	//combination of options
+	{"semantic.restart_rm", "Conflicting options: --restart and --rm", "参数冲突：--restart 和 --rm"},
+	{"semantic.attach_detach", "Conflicting options: -a and -d", "参数冲突：-a 和 -d"},
+	{"semantic.network_links", "conflicting options: %s type networking can't be used with links. This would result in undefined behavior", "参数冲突：{1} 网络模式不能与 --link 一起使用，否则行为未定义"},
+	{"semantic.container_links", "conflicting options: container type network can't be used with links. This would result in undefined behavior", "参数冲突：container 网络模式不能与 --link 一起使用，否则行为未定义"},
+	{"semantic.container_publish", "conflicting options: port publishing and the container type network mode", "参数冲突：container 网络模式下不能发布端口"},
+	{"semantic.container_hostname", "conflicting options: hostname and the network mode", "参数冲突：当前网络模式下不能设置主机名"},
+	{"semantic.ports_discarded", "Published ports are discarded when using %s network mode", "使用 {1} 网络模式时，发布的端口会被忽略"},
+	{"semantic.publish_all", "-P only publishes the ports exposed by the image %s, nothing is published if it have no EXPOSE", "-P 只会发布镜像 {1} 暴露的端口，镜像没有 EXPOSE 时不会发布任何端口"},
+	//security policy
+	{"policy.violation", "[%s] %s", "[{1}] {2}"},
+	{"policy.not_allowed", "%s %s is not allowed", "不允许 {1} {2}"},
+	{"policy.privileged", "--privileged is not allowed", "不允许使用 --privileged"},
+	{"policy.docker_sock", "mounting the docker socket is not allowed", "不允许挂载 docker socket"},
+	{"policy.host_root", "mounting the root of host is not allowed", "不允许挂载主机的根目录"},
+	{"policy.pid_host", "--pid host is not allowed", "不允许使用 --pid host"},
+	{"policy.capability", "adding this capability is not allowed", "不允许添加该 capability"},
+	{"policy.device", "--device is not allowed", "不允许使用 --device"},
+	{"policy.network_host", "--network host is not allowed", "不允许使用 --network host"},
+	//best practices
+	{"lint.BP001", "latest point to different images over time, pin a tag such as nginx:1.25 so that the container can be reproduced", "latest 在不同时间会指向不同的镜像，请指定标签（如 nginx:1.25）以便容器可以复现"},
+	{"lint.BP002", "a container without memory limit can use all memory of the host, set it by -m or --memory", "没有内存限制的容器可以用尽主机的内存，请用 -m 或 --memory 设置"},
+	{"lint.BP003", "a process run as root in container is root on the host if it escape, use -u or --user to run as a normal user", "容器中以 root 运行的进程逃逸后就是主机的 root，请用 -u 或 --user 以普通用户运行"},
+	{"lint.BP004", "the container of a one-off job is left after it exit, use --rm to remove it automatically", "一次性任务的容器退出后会被保留，请用 --rm 自动删除"},
+	{"lint.BP005", "a named volume is managed by docker and do not depend on the directory structure of host", "命名数据卷由 docker 管理，不依赖主机的目录结构"},
+	{"lint.BP006", "a service is not started again after it crash or the host reboot, use --restart unless-stopped or on-failure", "服务崩溃或主机重启后不会再次启动，请使用 --restart unless-stopped 或 on-failure"},
+	{"lint.no_tag", "images %s have no tag, latest is used", "镜像 {1} 没有指定标签，将使用 latest"},
+	{"lint.latest_tag", "images %s use the latest tag", "镜像 {1} 使用了 latest 标签"},
+	{"lint.no_memory", "no memory limit", "没有限制内存"},
+	{"lint.no_user", "the user is not set, the default user of most images is root", "没有设置用户，大多数镜像的默认用户是 root"},
+	{"lint.root", "run as root", "以 root 用户运行"},
+	{"lint.no_rm", "a foreground container without --rm", "前台运行的容器没有使用 --rm"},
+	{"lint.bind_mount", "bind mount %s:%s, consider a named volume", "绑定挂载了 {1}:{2}，建议使用命名数据卷"},
+	{"lint.no_restart", "a detached service without --restart", "后台运行的服务没有设置 --restart"},
+	//hints of the mistakes
+	{"suggest.missing_space_long", "missing space in %s, did you mean --%s %s?", "{1} 中缺少空格，是否应为 --{2} {3}？"},
+	{"suggest.missing_space_short", "missing space in %s, did you mean -%s %s?", "{1} 中缺少空格，是否应为 -{2} {3}？"},
+	{"suggest.unknown_long", "unknown flag --%s", "未知参数 --{1}"},
+	{"suggest.closest_long", "unknown flag --%s, did you mean --%s?", "未知参数 --{1}，是否应为 --{2}？"},
+	{"suggest.unknown_short", "unknown flag %s", "未知参数 {1}"},
+	{"suggest.closest_short", "unknown flag %s, did you mean --%s?", "未知参数 {1}，是否应为 --{2}？"},
+	{"suggest.two_dashes", "long flag need two dashes, did you mean --%s?", "长参数需要两个短横线，是否应为 --{1}？"},
+	{"suggest.publish_all_arg", "-P publish all exposed ports and take no argument, use -p %s to publish a port", "-P 会发布所有暴露的端口且不接受参数，发布指定端口请使用 -p {1}"},
+	{"suggest.publish_no_arg", "-p need a argument such as 8080:80, use -P to publish all exposed ports", "-p 需要一个参数，例如 8080:80，发布所有暴露的端口请使用 -P"},
+	{"suggest.publish_host_port", "-p %s has no host port, use -p hostPort:containerPort to publish a port", "-p {1} 缺少主机端口，请使用 -p 主机端口:容器端口 发布端口"},
+	{"suggest.value", "invalid argument %s for %s, did you mean %s?", "{2} 的参数 {1} 无效，是否应为 {3}？"},
+	{"suggest.swapped_port", "the host port is before the container port, did you mean -p %s:%s?", "主机端口应在容器端口之前，是否应为 -p {1}:{2}？"},
+	{"suggest.swapped_volume", "the host path is before the container path, did you mean -v %s:%s?", "主机路径应在容器路径之前，是否应为 -v {1}:{2}？"},
+	//judge result
+	{"judge.null_test", "Given pointer of test is null", "待评测的命令为空"},
+	{"judge.null_ans", "Given pointer of ans is null!", "答案命令为空！"},
+	{"judge.tty", "Not found -t or --tty.", "缺少 -t 或 --tty。"},
+	{"judge.detach", "Not found -d or --detach", "缺少 -d 或 --detach"},
+	{"judge.remove", "Not found --rm", "缺少 --rm"},
+	{"judge.interactive", "not found -i or --interactive", "缺少 -i 或 --interactive"},
+	{"judge.publish_all", "not found -P or --publish-all", "缺少 -P 或 --publish-all"},
+	{"judge.expect_create", "expect docker create but got docker run", "应该使用 docker create，而不是 docker run"},
+	{"judge.expect_run", "expect docker run but got docker create", "应该使用 docker run，而不是 docker create"},
+	{"judge.workdir", "WorkDir not right, expect '%s' but got '%s'.", "工作目录不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.name", "ContainerName not right, expect '%s' but got '%s'.", "容器名不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.user", "User not right, expect '%s' but got '%s'.", "用户不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.restart", "Restart policy not right, expect '%s' but got '%s'.", "重启策略不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.hostname", "HostName not right, expect '%s' but got '%s'.", "主机名不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.cpu_share", "CpuShare not right, expect %d but got %d", "CPU 份额不正确，应为 {1}，实际为 {2}"},
+	{"judge.memory", "Memory not right, expect %d m but got %d m", "内存限制不正确，应为 {1} m，实际为 {2} m"},
+	{"judge.port", "Port config not right, expect %s:%s but got %s", "端口映射不正确，应为 {1}:{2}，实际为 {3}"},
+	{"judge.volume", "Volume config not right, expect '%s':'%s' but got '%s'", "数据卷不正确，应为 '{1}':'{2}'，实际为 '{3}'"},
+	{"judge.volume_option", "Volume option not right, expect '%s' for %s but got '%s'", "数据卷选项不正确，{2} 应为 '{1}'，实际为 '{3}'"},
+	{"judge.volume_readonly", "Volume %s should not be read only", "数据卷 {1} 不应该是只读的"},
+	{"judge.platform", "Platform not right, expect '%s' but got '%s'.", "平台不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.pull", "Pull policy not right, expect '%s' but got '%s'.", "拉取策略不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.gpus", "Gpus not right, expect '%s' but got '%s'.", "GPU 设置不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.mount", "Not found mount %s", "缺少挂载 {1}"},
+	{"judge.privileged", "Privileged not right, expect %v but got %v.", "特权模式不正确，应为 {1}，实际为 {2}。"},
+	{"judge.pid", "Pid not right, expect '%s' but got '%s'.", "PID 模式不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.cap_add", "Not found --cap-add %s", "缺少 --cap-add {1}"},
+	{"judge.cap_drop", "Not found --cap-drop %s", "缺少 --cap-drop {1}"},
+	{"judge.device", "Not found --device %s", "缺少 --device {1}"},
+	{"judge.pod", "Pod not right, expect '%s' but got '%s'.", "Pod 不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.userns", "UserNS not right, expect '%s' but got '%s'.", "用户命名空间不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.images", "Images not right, expect '%s' but got '%s'.", "镜像不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.command", "Command not right, expect '%s' but got '%s'.", "命令不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.unexpect_command", "Unexpect command: %s", "多余的命令：{1}"},
+	{"judge.arg_number", "Arguments number not right, expect %d but got %d", "参数个数不正确，应为 {1}，实际为 {2}"},
+	{"judge.arg", "Arguments not right, expect '%s' but got '%s'.", "参数不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.command_pattern", "Command '%s' not match the pattern %s", "命令 '{1}' 不符合格式 {2}"},
+	{"judge.strategy", "unknown compare strategy: %s", "未知的命令比较方式：{1}"},
+	{"judge.pattern", "invalid command pattern: %v", "无效的命令格式：{1}"},
+	{"judge.client_null", "Given pointer of client config is null!", "客户端配置为空！"},
+	{"judge.host", "Not found -H %s", "缺少 -H {1}"},
+	{"judge.context", "Context not right, expect '%s' but got '%s'.", "上下文不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.log_level", "Log level not right, expect '%s' but got '%s'.", "日志级别不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.debug", "Not found -D or --debug", "缺少 -D 或 --debug"},
+	{"judge.tlsverify", "Not found --tlsverify", "缺少 --tlsverify"},
+	{"judge.flag", "Not found %s", "缺少 {1}"},
+	{"judge.action", "Command not right, expect 'docker %s' but got 'docker %s'.", "命令不正确，应为 'docker {1}'，实际为 'docker {2}'。"},
+	{"judge.container", "Container not right, expect '%s' but got '%s'.", "容器不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.container_missing", "Not found container %s", "缺少容器 {1}"},
+	{"judge.unexpect_container", "Unexpect container: %s", "多余的容器：{1}"},
+	{"judge.filter", "Not found filter %s", "缺少过滤条件 {1}"},
+	{"judge.format", "Format not right, expect '%s' but got '%s'.", "输出格式不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.timeout", "Timeout not right, expect '%s' but got '%s'.", "超时时间不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.signal", "Signal not right, expect '%s' but got '%s'.", "信号不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.tail", "Tail not right, expect '%s' but got '%s'.", "日志行数不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.since", "Since not right, expect '%s' but got '%s'.", "起始时间不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.until", "Until not right, expect '%s' but got '%s'.", "截止时间不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.build_context", "Build context not right, expect '%s' but got '%s'.", "构建上下文不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.tag", "Not found tag %s", "缺少标签 {1}"},
+	{"judge.unexpect_tag", "Unexpect tag: %s", "多余的标签：{1}"},
+	{"judge.dockerfile", "Dockerfile not right, expect '%s' but got '%s'.", "Dockerfile 不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.build_arg", "Build argument not right, expect %s=%s but got '%s'", "构建参数不正确，应为 {1}={2}，实际为 '{3}'"},
+	{"judge.unexpect_build_arg", "Unexpect build argument: %s", "多余的构建参数：{1}"},
+	{"judge.target", "Target not right, expect '%s' but got '%s'.", "构建阶段不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.images_number", "Images number not right, expect %d but got %d", "镜像个数不正确，应为 {1}，实际为 {2}"},
+	{"judge.images_missing", "Not found images %s", "缺少镜像 {1}"},
+	{"judge.unexpect_images", "Unexpect images: %s", "多余的镜像：{1}"},
+	{"judge.output", "Output not right, expect '%s' but got '%s'.", "输出文件不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.input", "Input not right, expect '%s' but got '%s'.", "输入文件不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.network_missing", "Not found network %s", "缺少网络 {1}"},
+	{"judge.unexpect_network", "Unexpect network: %s", "多余的网络：{1}"},
+	{"judge.driver", "Driver not right, expect '%s' but got '%s'.", "驱动不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.subnet", "Subnet not right, expect '%s' but got '%s'.", "子网不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.gateway", "Gateway not right, expect '%s' but got '%s'.", "网关不正确，应为 '{1}'，实际为 '{2}'。"},
+	{"judge.alias", "Not found alias %s", "缺少别名 {1}"},
+	{"judge.volume_missing", "Not found volume %s", "缺少数据卷 {1}"},
+	{"judge.unexpect_volume", "Unexpect volume: %s", "多余的数据卷：{1}"},
+	{"judge.driver_opt", "Driver option not right, expect %s=%s but got '%s'", "驱动选项不正确，应为 {1}={2}，实际为 '{3}'"},
+	{"judge.unexpect_driver_opt", "Unexpect driver option: %s", "多余的驱动选项：{1}"},
+	//final state of the engine and a series of commands
+	{"judge.engine_null", "Given pointer of engine is null!", "引擎为空！"},
+	{"engine.command", "command %d: %v", "第 {1} 条命令：{2}"},
+	{"engine.container_missing", "Container %s not found.", "找不到容器 {1}。"},
+	{"engine.state", "Container %s should be %s but it is %s.", "容器 {1} 应为 {2} 状态，实际为 {3}。"},
+	{"engine.container", "Container %s: %s", "容器 {1}：{2}"},
+	{"engine.like", "Not found a %s container of images %s like the answer.", "找不到与答案相同的 {1} 状态的 {2} 镜像容器。"},
+	{"engine.network_missing", "Network %s not found.", "找不到网络 {1}。"},
+	{"engine.volume_missing", "Volume %s not found.", "找不到数据卷 {1}。"},
+	{"engine.not_connected", "Not connected to network %s", "没有连接到网络 {1}"},
+	{"sequence.number", "Commands number not right, expect %d but got %d", "命令条数不正确，应为 {1}，实际为 {2}"},
+	{"sequence.command", "Command %d: %s", "第 {1} 条命令：{2}"},
+	{"sequence.missing", "Not found command %d like '%s' in the right order", "按顺序找不到第 {1} 条命令 '{2}'"},
+	{"sequence.mode", "Unknown sequence mode: %s", "未知的命令序列比较方式：{1}"},
+	//exercise
+	{"exercise.answer", "The answer is worng: %v", "答案有误：{1}"},
+	{"exercise.config", "The exercise is worng: %v", "题目设置有误：{1}"},
+	{"exercise.cli", "%s is not allowed in this exercise, please use %s", "本题不允许使用 {1}，请使用 {2}"},
+}
+
+//Catalog is the messages of the locales, the wording of a message can be overridden by the course staff
+type Catalog struct {
+	overrides map[string]map[string]string //locale -> message id -> wording
+}
+
+//the catalog that used when an exercise have no catalog
+var defaultCatalog = NewCatalog()
+
+//create a catalog that only have the built-in messages
+func NewCatalog() *Catalog {
+	return &Catalog{overrides: map[string]map[string]string{}}
+}
+
+//override the wording of a message in a locale, the parameters are written as {1}, {2}
+func (this *Catalog) Override(locale, id, wording string) error {
+	if findMessage(id) == nil {
+		return fmt.Errorf("unknown message id: %s", id)
+	}
+	if this.overrides[locale] == nil {
+		this.overrides[locale] = map[string]string{}
+	}
+	this.overrides[locale][id] = wording
+	return nil
+}
+
+//read the overridden wording from yaml, the keys of the top level are locales and the keys under them are message ids, such as:
+//zh-CN:
+//
+//	judge.remove: 容器退出后需要自动删除，请加上 --rm
+func LoadCatalog(text string) (*Catalog, error) {
+	doc, err := parseYAML(text)
+	if err != nil {
+		return nil, err
+	}
+	root, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("catalog should be a mapping")
+	}
+	catalog := NewCatalog()
+	for locale, value := range root {
+		messages, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("messages of %s should be a mapping", locale)
+		}
+		for id, wording := range messages {
+			if err = catalog.Override(locale, id, composeString(wording)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return catalog, nil
+}
+
+//return the message of the id in the locale with the parameters,
+//the English message is used if the locale have no translation
+func (this *Catalog) Message(locale, id string, args ...interface{}) string {
+	def := findMessage(id)
+	if def == nil {
+		return id
+	}
+	wording := this.overrides[locale][id]
+	if wording == "" && locale == LocaleChinese {
+		wording = def.Chinese
+	}
+	if wording == "" {
+		wording = this.overrides[LocaleEnglish][id]
+	}
+	if wording == "" {
+		wording = placeholders(def.English)
+	}
+	return parameterReg.ReplaceAllStringFunc(wording, func(p string) string { //in one pass so that a parameter like {2} is kept
+		i, _ := strconv.Atoi(p[1 : len(p)-1])
+		if i < 1 || i > len(args) {
+			return p
+		}
+		return fmt.Sprint(args[i-1])
+	})
+}
+
+//the parameters of a translation such as {1}
+var parameterReg = regexp.MustCompile(`\{[0-9]+\}`)
+
+//return the message in the locale, the parameters that are messages too are in the same locale
+func (this *Catalog) Render(locale string, message Message) string {
+	args := make([]interface{}, len(message.Args))
+	for i, arg := range message.Args {
+		if m, ok := arg.(Message); ok {
+			arg = this.Render(locale, m)
+		}
+		args[i] = arg
+	}
+	return this.Message(locale, message.ID, args...)
+}
+
+//return the messages in the locale
+func (this *Catalog) RenderAll(locale string, messages []Message) []string {
+	result := []string{}
+	for _, m := range messages {
+		result = append(result, this.Render(locale, m))
+	}
+	return result
+}
+
+//Message is a feedback message produced by the code, it is the id in the catalog with the parameters
+//so that it can be rendered in any locale, the zero value means no message
+type Message struct {
+	ID   string
+	Args []interface{}
+}
+
+//create a message of the id with the parameters
+func msg(id string, args ...interface{}) Message {
+	return Message{id, args}
+}
+
+//return the message itself if the error is a message, or a message of the error text
+func toMessage(err error) Message {
+	if m, ok := err.(Message); ok {
+		return m
+	}
+	return msg("text", err.Error())
+}
+
+//return a message of the id if the text is a message id, or a message of the text itself,
+//so that the wording written by the course staff such as the message of a policy rule can be a message id
+func textMessage(text string) Message {
+	if findMessage(text) != nil {
+		return msg(text)
+	}
+	return msg("text", text)
+}
+
+//check if the same message is in the list
+func containMessage(messages []Message, message Message) bool {
+	for _, m := range messages {
+		if m.String() == message.String() {
+			return true
+		}
+	}
+	return false
+}
+
+//check if it is the zero value that means no message
+func (this Message) IsEmpty() bool {
+	return this.ID == ""
+}
+
+//return the message in English
+func (this Message) String() string {
+	if this.IsEmpty() {
+		return ""
+	}
+	return defaultCatalog.Render(LocaleEnglish, this)
+}
+
+//a message can be returned as an error
+func (this Message) Error() string {
+	return this.String()
+}
+
+//change the verbs of a format such as %s into the parameters {1}, {2}
+func placeholders(format string) string {
+	n := 0
+	return regexp.MustCompile(`%[sdv]`).ReplaceAllStringFunc(format, func(string) string {
+		n++
+		return "{" + strconv.Itoa(n) + "}"
+	})
+}
+
+//return the message of the id in the built-in catalog
+func findMessage(id string) *MessageDef {
+	for i := range Messages {
+		if Messages[i].ID == id {
+			return &Messages[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package DockerRun
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestCatalog(t *testing.T) {
+	catalog := NewCatalog()
+	testCase := []struct {
+		message Message
+		english string
+		chinese string
+	}{
+		{msg("judge.remove"), "Not found --rm", "缺少 --rm"},
+		{msg("judge.port", "8080", "80", "80"), "Port config not right, expect 8080:80 but got 80", "端口映射不正确，应为 8080:80，实际为 80"},
+		{msg("judge.volume_option", "ro", "$PWD", ""), "Volume option not right, expect 'ro' for $PWD but got ''", "数据卷选项不正确，$PWD 应为 'ro'，实际为 ''"},
+		{msg("judge.memory", 512, 0), "Memory not right, expect 512 m but got 0 m", "内存限制不正确，应为 512 m，实际为 0 m"},
+		{msg("flag.unknown", "--rmv"), "Unknown flag: --rmv", "未知参数：--rmv"},
+		{msg("exercise.answer", msg("flag.unknown", "--rmv")), "The answer is worng: Unknown flag: --rmv", "答案有误：未知参数：--rmv"},
+		{msg("judge.arg", "{2}", "a"), "Arguments not right, expect '{2}' but got 'a'.", "参数不正确，应为 '{2}'，实际为 'a'。"},
+		{msg("text", "a message not in catalog"), "a message not in catalog", "a message not in catalog"},
+	}
+	for i, c := range testCase {
+		if res := catalog.Render(LocaleChinese, c.message); res != c.chinese {
+			t.Fatalf("worng translation at message %d : %s", i, res)
+		}
+		if res := catalog.Render(LocaleEnglish, c.message); res != c.english || c.message.String() != c.english {
+			t.Fatalf("worng English message at message %d : %s", i, res)
+		}
+	}
+	verbReg, parameterReg := regexp.MustCompile(`%[sdv]`), regexp.MustCompile(`\{[0-9]+\}`)
+	for _, def := range Messages {
+		if findMessage(def.ID) != &Messages[indexOfMessage(def.ID)] {
+			t.Fatalf("duplicate message id %s", def.ID)
+		}
+		if len(verbReg.FindAllString(def.English, -1)) != len(parameterReg.FindAllString(def.Chinese, -1)) {
+			t.Fatalf("the parameters of message %s are not the same in English and Chinese", def.ID)
+		}
+	}
+}
+
+//all the message ids used by the code should be in the catalog
+func TestMessageIDs(t *testing.T) {
+	files, _ := filepath.Glob("*.go")
+	idReg := regexp.MustCompile(`msg\("([^"]*)"`)
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatalf("read %s fail: %v", file, err)
+		}
+		for _, match := range idReg.FindAllStringSubmatch(string(data), -1) {
+			if findMessage(match[1]) == nil {
+				t.Fatalf("message id %s used in %s is not in the catalog", match[1], file)
+			}
+		}
+	}
+	//the reasons of parsing and judging are message ids so that they can be translated
+	funcReg := regexp.MustCompile(`(?m)^func (\(this \*\w+\) (basicCheck|HandleArgument|HandleFlag)|judge\w*)\(`)
+	for _, file := range files {
+		data, _ := ioutil.ReadFile(file)
+		text := string(data)
+		for _, loc := range funcReg.FindAllStringIndex(text, -1) {
+			body := text[loc[0]:]
+			body = body[:strings.Index(body, "\n}\n")]
+			if strings.Contains(body, "fmt.Sprintf(") || strings.Contains(body, "fmt.Errorf(") {
+				t.Fatalf("%s in %s return a text that is not in the catalog", strings.SplitN(body, "\n", 2)[0], file)
+			}
+		}
+	}
+	for _, rule := range DefaultSecurityPolicy().Rules {
+		if findMessage(rule.Message) == nil {
+			t.Fatalf("the message of policy rule %s is not in the catalog", rule.ID)
+		}
+	}
+	for _, rule := range LintRules {
+		if findMessage("lint."+rule.ID) == nil {
+			t.Fatalf("the explanation of lint rule %s is not in the catalog", rule.ID)
+		}
+	}
+}
+
+func indexOfMessage(id string) int {
+	for i := range Messages {
+		if Messages[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestCatalogOverride(t *testing.T) {
+	catalog, err := LoadCatalog(`
+zh-CN:
+  judge.remove: 容器退出后需要自动删除，请加上 --rm
+en:
+  judge.images: "Please use the image {1} instead of {2}"
+`)
+	if err != nil {
+		t.Fatalf("load catalog fail: %v", err)
+	}
+	if res := catalog.Render(LocaleChinese, msg("judge.remove")); res != "容器退出后需要自动删除，请加上 --rm" {
+		t.Fatalf("worng overridden message: %s", res)
+	}
+	if res := catalog.Render("fr", msg("judge.images", "nginx:latest", "httpd:latest")); res != "Please use the image nginx:latest instead of httpd:latest" {
+		t.Fatalf("worng fallback message: %s", res)
+	}
+	if _, err = LoadCatalog("en:\n  judge.unknown: hello\n"); err == nil {
+		t.Fatalf("unknown message id is accepted")
+	}
+	exercise := Exercise{Answer: `docker run -it --rm ubuntu`, Locale: LocaleChinese}
+	if res := exercise.Judge(`docker run -it ubuntu`); res != "缺少 --rm" {
+		t.Fatalf("worng judge result: %s", res)
+	}
+	if res := exercise.CheckIn(`docker run -it ubuntu`, LocaleEnglish).Reason; res != "Not found --rm" {
+		t.Fatalf("worng judge result: %s", res)
+	}
+	exercise.Catalog = catalog
+	if res := exercise.Judge(`docker run -it ubuntu`); res != "容器退出后需要自动删除，请加上 --rm" {
+		t.Fatalf("worng judge result: %s", res)
+	}
+}
+
+func TestExerciseLocale(t *testing.T) {
+	exercise := Exercise{Answer: `docker run -d --name web nginx`, Locale: LocaleChinese, Version: "docker 19.03", Policy: DefaultSecurityPolicy()}
+	reasons := map[string]string{
+		`docker run -d --rm --restart always --name web nginx`:  "参数冲突：--restart 和 --rm",
+		`docker -H tcp://lab:x run -d --name web nginx`:         "无效的守护进程地址格式：tcp://lab:x",
+		`docker run -d --privileged --name web nginx`:           "[SEC001] 不允许使用 --privileged",
+		`docker run -d --platform linux/amd64 --name web nginx`: "--platform 需要 docker 20.10 或更高版本，但目标版本是 docker 19.03",
+		`docker run -d --name web httpd`:                        "镜像不正确，应为 'nginx:latest'，实际为 'httpd:latest'。",
+	}
+	for cmd, expect := range reasons {
+		if res := exercise.Judge(cmd); res != expect {
+			t.Fatalf("command '%s' expect '%s' but got: %s", cmd, expect, res)
+		}
+	}
+	result := exercise.Check(`docker run -d --link db:db --name web nginx`)
+	if result.Reason != "" || len(result.Warnings) != 1 || result.Warnings[0] != "--link 是遗留功能，请改用自定义网络" {
+		t.Fatalf("worng warnings: %v", result)
+	}
+	if len(result.Lint) == 0 || result.Lint[0].Message != "镜像 nginx:latest 没有指定标签，将使用 latest" || !strings.HasPrefix(result.Lint[0].Explanation, "latest 在不同时间") {
+		t.Fatalf("worng lint: %v", result.Lint)
+	}
+	result = exercise.Check(`docker run -d --rmv --name web nginx`)
+	if result.Reason != "未知参数：--rmv" || len(result.Hints) != 1 || result.Hints[0] != "未知参数 --rmv，是否应为 --rm？" {
+		t.Fatalf("worng hints: %v", result)
+	}
+	exercise = Exercise{Answer: `docker build -t app:v1 --build-arg ENV=prod .`, Locale: LocaleChinese}
+	reasons = map[string]string{
+		`docker build -t app:v1 --build-arg ENV=prod .`:     "",
+		`docker build -t app:v2 --build-arg ENV=prod .`:     "缺少标签 app:v1",
+		`docker build -t app:v1 --build-arg ENV=dev .`:      "构建参数不正确，应为 ENV=prod，实际为 'dev'",
+		`docker build -t app:v1 --build-arg ENV=prod`:       "\"docker build\" 需要且只需要 1 个参数。",
+		`docker build -t app:v1 --build-arg ENV=prod --x .`: "未知参数：--x",
+		`docker network create web`:                         "命令不正确，应为 build 命令，实际为：docker network create web",
+	}
+	for cmd, expect := range reasons {
+		if res := exercise.Judge(cmd); res != expect {
+			t.Fatalf("command '%s' expect '%s' but got: %s", cmd, expect, res)
+		}
+	}
+	if res := exercise.CheckIn(`docker build -t app:v2 --build-arg ENV=prod .`, LocaleEnglish).Reason; res != "Not found tag app:v1" {
+		t.Fatalf("worng English judge result: %s", res)
+	}
+	exercise = Exercise{Answer: `docker build -t app:v1`, Locale: LocaleChinese}
+	if err := exercise.Validate(); err == nil || exercise.Judge(`docker build -t app:v1 .`) != "答案有误：\"docker build\" 需要且只需要 1 个参数。" {
+		t.Fatalf("worng answer pass: %v", err)
+	}
+	if res := JudgeScript("docker network create web\ndocker volume create data", "docker network create web\ndocker volume create db", SequenceExact); res != "Command 2: Not found volume db" {
+		t.Fatalf("worng sequence result: %s", res)
+	}
+}
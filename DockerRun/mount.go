@@ -0,0 +1,55 @@
+package DockerRun
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//the key of --mount that have other names, such as src is the same as source
+var mountKeyAlias = map[string]string{
+	"src": "source", "dst": "target", "destination": "target", "ro": "readonly",
+}
+
+//explain the argument of --mount such as type=bind,source=$PWD,target=/app into a map with canonical keys,
+//the default type is volume and readonly is true if it appear without a value
+func parseMount(arg string) (map[string]string, error) {
+	mount := map[string]string{"type": "volume"}
+	for _, field := range strings.Split(arg, ",") {
+		kv := strings.SplitN(field, "=", 2)
+		key := strings.ToLower(kv[0])
+		if alias, have := mountKeyAlias[key]; have {
+			key = alias
+		}
+		if len(kv) == 1 {
+			if key != "readonly" {
+				return nil, fmt.Errorf("invalid field '%s' must be a key=value pair", field)
+			}
+			kv = append(kv, "true")
+		}
+		mount[key] = kv[1]
+	}
+	if !findInArray([]string{"bind", "volume", "tmpfs", "image", "npipe", "cluster"}, mount["type"]) {
+		return nil, fmt.Errorf("invalid mount type: %s", mount["type"])
+	}
+	if mount["target"] == "" {
+		return nil, fmt.Errorf("target is required")
+	}
+	if mount["type"] == "bind" && mount["source"] == "" {
+		return nil, fmt.Errorf("source is required when specifying bind mount")
+	}
+	if mount["type"] == "tmpfs" && mount["source"] != "" {
+		return nil, fmt.Errorf("source must not be specified for tmpfs mount")
+	}
+	return mount, nil
+}
+
+//return a mount in the form of its argument with the keys in order, such as target=/tmp,type=tmpfs
+func formatMount(mount map[string]string) string {
+	fields := []string{}
+	for k, v := range mount {
+		fields = append(fields, k+"="+v)
+	}
+	sort.Strings(fields)
+	return strings.Join(fields, ",")
+}
@@ -0,0 +1,33 @@
+package DockerRun
+
+import "testing"
+
+func TestMount(t *testing.T) {
+	ans, err := NewMockContainer(`docker run -v $PWD/app:/app:ro -v data:/data --mount type=tmpfs,destination=/tmp mysql`)
+	if err != nil {
+		t.Fatalf("Standard command fall: %v", err)
+	}
+	for i, cmd := range []string{
+		`docker run --mount type=bind,source=$PWD/app,target=/app,readonly --mount source=data,target=/data --mount type=tmpfs,dst=/tmp mysql`,
+		`docker run --mount type=bind,src=$PWD/app,dst=/app,ro=true -v data:/data --mount target=/tmp,type=tmpfs mysql`,
+	} {
+		ctr, err := NewMockContainer(cmd)
+		if err != nil {
+			t.Fatalf("Create container fail at command %d : %v", i, err)
+		}
+		if res := Judge(&ctr, &ans); res != "" {
+			t.Fatalf("Unpass at command %d : %v", i, res)
+		}
+	}
+	for _, cmd := range []string{
+		`docker run --mount type=bind,target=/app mysql`,
+		`docker run --mount type=nfs,target=/app mysql`,
+		`docker run --mount type=tmpfs,source=x,target=/tmp mysql`,
+		`docker run --mount source=data mysql`,
+		`docker run --mount type=bind,source,target=/app mysql`,
+	} {
+		if _, err := NewMockContainer(cmd); err == nil {
+			t.Fatalf("worng command %s pass!", cmd)
+		}
+	}
+}
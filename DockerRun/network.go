@@ -0,0 +1,380 @@
+package DockerRun
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+//NetworkFlags is all flags allowled to used in the docker network commands
+var NetworkFlags = map[string]FlagSet{
+	"create": {
+		Short: []string{"d", "o"},
+		Long:  []string{"driver", "subnet", "gateway", "internal", "attachable", "label", "opt"},
+		NoArg: []string{"internal", "attachable"},
+	},
+	"ls": {
+		Short: []string{"q", "f"},
+		Long:  []string{"quiet", "filter", "format", "no-trunc"},
+		NoArg: []string{"q", "quiet", "no-trunc"},
+	},
+	"rm":         {Short: []string{"f"}, Long: []string{"force"}, NoArg: []string{"f", "force"}},
+	"connect":    {Long: []string{"alias", "ip", "link"}},
+	"disconnect": {Short: []string{"f"}, Long: []string{"force"}, NoArg: []string{"f", "force"}},
+	"inspect":    {Short: []string{"f"}, Long: []string{"format"}},
+}
+
+//the alias of the docker network commands
+var networkActionAlias = map[string]string{"list": "ls", "remove": "rm"}
+
+//the model to simulate the property of a docker network command: create, ls, rm, connect, disconnect, inspect
+type MockNetwork struct {
+	Action     string
+	Names      []string
+	Container  string //the container of connect and disconnect
+	Driver     string
+	Subnet     string
+	Gateway    string
+	Aliases    []string
+	Label      []string
+	Options    map[string]string
+	Filters    []string
+	Format     string
+	IsInternal bool
+	IsQuiet    bool
+	IsForce    bool
+	Client     ClientConfig //the global options before the subcommand
+}
+
+//create an MockNetwork according to a docker network command, return error if it command have a worng syntax
+func NewMockNetwork(dockerCmd string) (model MockNetwork, err error) {
+	model.Options = make(map[string]string)
+	cmdArray := splitCommand(dockerCmd)
+	if reason := model.basicCheck(cmdArray); !reason.IsEmpty() {
+		return model, reason
+	}
+	return model, nil
+}
+
+//check the basic syntax of a docker network command,
+//return the fall reason or return a empty string if the command is accpeted
+//synatax: docker network create [OPTIONS] NETWORK, docker network ls [OPTIONS], docker network rm|inspect NETWORK [NETWORK...]
+//and docker network connect|disconnect [OPTIONS] NETWORK CONTAINER
+func (this *MockNetwork) BasicCheck(cmd []string) string {
+	return this.basicCheck(cmd).String()
+}
+
+//check the basic syntax of a docker network command, return the fall reason or a empty message if the command is accpeted
+func (this *MockNetwork) basicCheck(cmd []string) Message {
+	if len(cmd) == 0 {
+		return msg("run.empty")
+	}
+	if len(cmd) < 2 {
+		return msg("run.too_short")
+	}
+	if cmd[0] != "docker" {
+		return msg("run.not_docker")
+	}
+	cmd, reason := stripGlobalOptions(cmd, &this.Client)
+	if !reason.IsEmpty() {
+		return reason
+	}
+	if len(cmd) < 3 {
+		return msg("command.too_short")
+	}
+	if cmd[1] != "network" {
+		return msg("command.not_kind", "network")
+	}
+	this.Action = cmd[2]
+	if alias, have := networkActionAlias[this.Action]; have {
+		this.Action = alias
+	}
+	flagSet, have := NetworkFlags[this.Action]
+	if !have {
+		return msg("command.unknown_action", "network", cmd[2])
+	}
+	nowAt, reason := parseOptions(cmd, 3, flagSet, this)
+	if !reason.IsEmpty() {
+		return reason
+	}
+	args := []string{}
+	for _, a := range cmd[nowAt:] {
+		a = trimStr(a)
+		if !isContainerName(a) {
+			return msg("arg.object_name", a)
+		}
+		args = append(args, a)
+	}
+	switch this.Action {
+	case "create":
+		if len(args) != 1 {
+			return msg("command.exactly_one", "network create")
+		}
+	case "ls":
+		if len(args) != 0 {
+			return msg("command.no_arg", "network ls")
+		}
+	case "rm", "inspect":
+		if len(args) == 0 {
+			return msg("command.at_least_one", "network "+this.Action)
+		}
+	case "connect", "disconnect":
+		if len(args) != 2 {
+			return msg("command.exactly_two", "network "+this.Action)
+		}
+		this.Container = args[1]
+		args = args[:1]
+	}
+	this.Names = args
+	if this.Gateway != "" && this.Subnet == "" {
+		return msg("arg.gateway", this.Gateway)
+	}
+	if this.Gateway != "" {
+		_, subnet, _ := net.ParseCIDR(this.Subnet)
+		if !subnet.Contains(net.ParseIP(this.Gateway)) {
+			return msg("arg.gateway", this.Gateway)
+		}
+	}
+	return Message{}
+}
+
+//Setting up the property according to the flag and argument
+//if the format of arguments not right it will return error
+func (this *MockNetwork) HandleArgument(flag, arg string) error {
+	arg = trimStr(arg)
+	switch flag {
+	case "d", "driver":
+		if !findInArray([]string{"bridge", "overlay", "macvlan", "ipvlan", "host", "none"}, arg) {
+			return msg("arg.network_driver", arg)
+		}
+		this.Driver = arg
+	case "subnet":
+		if _, _, err := net.ParseCIDR(arg); err != nil {
+			return msg("arg.cidr", arg)
+		}
+		this.Subnet = arg
+	case "gateway", "ip":
+		if net.ParseIP(arg) == nil {
+			return msg("arg.ip", arg)
+		}
+		if flag == "gateway" {
+			this.Gateway = arg
+		}
+	case "alias":
+		this.Aliases = append(this.Aliases, arg)
+	case "label":
+		this.Label = append(this.Label, arg)
+	case "o", "opt":
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return msg("arg.driver_opt", arg)
+		}
+		this.Options[kv[0]] = kv[1]
+	case "link":
+	case "f", "filter", "format":
+		if this.Action == "ls" && flag != "format" {
+			if !strings.Contains(arg, "=") {
+				return msg("arg.filter")
+			}
+			this.Filters = append(this.Filters, arg)
+		} else {
+			this.Format = arg
+		}
+	default:
+		return msg("flag.invalid", flag)
+	}
+	return nil
+}
+
+//Setting up the property according to the flag that without argument
+//return error only if the flag is not exist
+func (this *MockNetwork) HandleFlag(flag string) error {
+	switch flag {
+	case "internal":
+		this.IsInternal = true
+	case "q", "quiet":
+		this.IsQuiet = true
+	case "f", "force":
+		this.IsForce = true
+	case "attachable", "no-trunc":
+	default:
+		return msg("flag.invalid_short", flag)
+	}
+	return nil
+}
+
+//judge if the property of a docker network command is right by compared to the answer
+//return a string to describe the mistake or a null string if it command is accepted
+//note that here we have some config do not check: Label[] and Options[]
+func JudgeNetwork(test, ans *MockNetwork) string {
+	return judgeNetwork(test, ans).String()
+}
+
+//judge the property of a docker network command, return the mistake or a empty message if it command is accepted
+func judgeNetwork(test, ans *MockNetwork) Message {
+	if test == nil {
+		return msg("judge.null_test")
+	}
+	if ans == nil {
+		return msg("judge.null_ans")
+	}
+	if test.Action != ans.Action {
+		return msg("judge.action", "network "+ans.Action, "network "+test.Action)
+	}
+	for _, n := range ans.Names {
+		if !findInArray(test.Names, n) {
+			return msg("judge.network_missing", n)
+		}
+	}
+	for _, n := range test.Names {
+		if !findInArray(ans.Names, n) {
+			return msg("judge.unexpect_network", n)
+		}
+	}
+	if test.Container != ans.Container {
+		return msg("judge.container", ans.Container, test.Container)
+	}
+	if normalizeNetworkDriver(test.Driver) != normalizeNetworkDriver(ans.Driver) {
+		return msg("judge.driver", normalizeNetworkDriver(ans.Driver), normalizeNetworkDriver(test.Driver))
+	}
+	if ans.Subnet != "" && test.Subnet != ans.Subnet {
+		return msg("judge.subnet", ans.Subnet, test.Subnet)
+	}
+	if ans.Gateway != "" && test.Gateway != ans.Gateway {
+		return msg("judge.gateway", ans.Gateway, test.Gateway)
+	}
+	for _, a := range ans.Aliases {
+		if !findInArray(test.Aliases, a) {
+			return msg("judge.alias", a)
+		}
+	}
+	for _, f := range ans.Filters {
+		if !findInArray(test.Filters, f) {
+			return msg("judge.filter", f)
+		}
+	}
+	if ans.IsInternal && !test.IsInternal {
+		return msg("judge.flag", "--internal")
+	}
+	if ans.IsQuiet && !test.IsQuiet {
+		return msg("judge.flag", "-q or --quiet")
+	}
+	if ans.IsForce && !test.IsForce {
+		return msg("judge.flag", "-f or --force")
+	}
+	if ans.Format != "" && test.Format != ans.Format {
+		return msg("judge.format", ans.Format, test.Format)
+	}
+	if reason := judgeClient(&test.Client, &ans.Client); !reason.IsEmpty() {
+		return reason
+	}
+	return Message{}
+}
+
+//apply a docker network command to the engine, return the output of the command
+func (this *MockEngine) ManageNetwork(m *MockNetwork) (string, error) {
+	output := ""
+	switch m.Action {
+	case "create":
+		name := m.Names[0]
+		if this.Networks[name] {
+			return "", fmt.Errorf("network with name %s already exists", name)
+		}
+		this.Networks[name] = true
+		if this.networkConfig == nil {
+			this.networkConfig = make(map[string]*MockNetwork)
+		}
+		this.networkConfig[name] = m
+		output = name + "\n"
+	case "ls":
+		if !m.IsQuiet {
+			output = fmt.Sprintf("%-20s   %s\n", "NAME", "DRIVER")
+		}
+		for _, name := range sortedSet(this.Networks) {
+			if m.IsQuiet {
+				output += name + "\n"
+			} else {
+				output += fmt.Sprintf("%-20s   %s\n", name, this.networkDriver(name))
+			}
+		}
+	case "rm":
+		for _, name := range m.Names {
+			if !this.Networks[name] {
+				if m.IsForce {
+					continue
+				}
+				return output, fmt.Errorf("network %s not found", name)
+			}
+			if findInArray(defaultNetworks, name) {
+				return output, fmt.Errorf("%s is a pre-defined network and cannot be removed", name)
+			}
+			for _, c := range this.Containers {
+				if c.State == StateRunning && findInArray(c.Networks, name) {
+					return output, fmt.Errorf("error while removing network: network %s has active endpoints (name:%q)", name, c.Name)
+				}
+			}
+			delete(this.Networks, name)
+			delete(this.networkConfig, name)
+			output += name + "\n"
+		}
+	case "inspect":
+		for _, name := range m.Names {
+			if !this.Networks[name] {
+				return output, fmt.Errorf("network %s not found", name)
+			}
+			output += fmt.Sprintf("%s %s\n", name, this.networkDriver(name))
+		}
+	case "connect", "disconnect":
+		name := m.Names[0]
+		if !this.Networks[name] {
+			return "", fmt.Errorf("network %s not found", name)
+		}
+		c := this.Container(m.Container)
+		if c == nil {
+			return "", fmt.Errorf("No such container: %s", m.Container)
+		}
+		connected := findInArray(c.Networks, name)
+		if m.Action == "connect" {
+			if connected {
+				return "", fmt.Errorf("endpoint with name %s already exists in network %s", c.Name, name)
+			}
+			if name == "host" || name == "none" || findInArray(c.Networks, "host") || findInArray(c.Networks, "none") {
+				return "", fmt.Errorf("container cannot be disconnected from host network or connected to host network")
+			}
+			c.Networks = append(c.Networks, name)
+			return "", nil
+		}
+		if !connected {
+			if m.IsForce {
+				return "", nil
+			}
+			return "", fmt.Errorf("container %s is not connected to network %s", c.ID, name)
+		}
+		for i, n := range c.Networks {
+			if n == name {
+				c.Networks = append(c.Networks[:i], c.Networks[i+1:]...)
+				break
+			}
+		}
+	}
+	return output, nil
+}
+
+//return the driver of a network in engine
+func (this *MockEngine) networkDriver(name string) string {
+	if name == "host" || name == "none" {
+		return name
+	}
+	if m, have := this.networkConfig[name]; have {
+		return normalizeNetworkDriver(m.Driver)
+	}
+	return "bridge"
+}
+
+//the default driver of network is bridge
+func normalizeNetworkDriver(driver string) string {
+	if driver == "" {
+		return "bridge"
+	}
+	return driver
+}
@@ -0,0 +1,88 @@
+package DockerRun
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMockNetwork(t *testing.T) {
+	standard := `docker network create --driver bridge --subnet 172.20.0.0/16 netname`
+	challenger := []string{
+		`docker network create --subnet=172.20.0.0/16 netname`,
+		`docker network create -d bridge --subnet 172.20.0.0/16 --label env=dev netname`,
+		`sudo docker network create --subnet 172.20.0.0/16 --gateway 172.20.0.1 "netname"`,
+	}
+	stdNetwork, err := NewMockNetwork(standard)
+	if err != nil {
+		t.Fatalf("Standard command fall: %v", err)
+	}
+	for i, cmd := range challenger {
+		n, err := NewMockNetwork(cmd)
+		if err != nil {
+			t.Fatalf("Create network fail at command %d : %v", i, err)
+		}
+		if res := JudgeNetwork(&n, &stdNetwork); res != "" {
+			t.Fatalf("Unpass at command %d : %v", i, res)
+		}
+	}
+	for _, cmd := range []string{
+		`docker network create`,
+		`docker network create --subnet 172.20.0.0 netname`,
+		`docker network create --subnet 172.20.0.0/16 --gateway 10.0.0.1 netname`,
+		`docker network create -d nothing netname`,
+		`docker network connect netname`,
+		`docker network ls netname`,
+		`docker network prune2`,
+	} {
+		if _, err := NewMockNetwork(cmd); err == nil {
+			t.Fatalf("worng command %s pass!", cmd)
+		}
+	}
+	n, _ := NewMockNetwork(`docker network create --internal -d overlay netname`)
+	if JudgeNetwork(&n, &stdNetwork) == "" {
+		t.Fatalf("overlay network should not pass")
+	}
+	n, _ = NewMockNetwork(`docker network remove netname`)
+	if n.Action != "rm" {
+		t.Fatalf("network remove should be the same as rm, got %s", n.Action)
+	}
+}
+
+func TestMockEngineNetwork(t *testing.T) {
+	engine := NewMockEngine()
+	expect := [][2]string{
+		{`docker network create netname`, ``},
+		{`docker network create netname`, `already exists`},
+		{`docker network rm bridge`, `pre-defined network`},
+		{`docker network rm missing`, `network missing not found`},
+		{`docker network connect netname server1`, `No such container: server1`},
+	}
+	for _, e := range expect {
+		n, err := NewMockNetwork(e[0])
+		if err != nil {
+			t.Fatalf("Create network fail at command %s : %v", e[0], err)
+		}
+		_, err = engine.ManageNetwork(&n)
+		if (e[1] == "" && err != nil) || (e[1] != "" && (err == nil || !strings.Contains(err.Error(), e[1]))) {
+			t.Fatalf("command '%s' expect error '%s' but got: %v", e[0], e[1], err)
+		}
+	}
+	if err := engine.RunCommands([]string{`docker run -d --network netname --name server1 mysql`}); err != nil {
+		t.Fatalf("run on created network fail: %v", err)
+	}
+	n, _ := NewMockNetwork(`docker network rm netname`)
+	if _, err := engine.ManageNetwork(&n); err == nil || !strings.Contains(err.Error(), "active endpoints") {
+		t.Fatalf("remove a network in use expect error but got: %v", err)
+	}
+	n, _ = NewMockNetwork(`docker network connect bridge server1`)
+	if _, err := engine.ManageNetwork(&n); err != nil {
+		t.Fatalf("connect fail: %v", err)
+	}
+	if c := engine.Container("server1"); !findInArray(c.Networks, "bridge") || !findInArray(c.Networks, "netname") {
+		t.Fatalf("unexpect networks of server1: %v", c.Networks)
+	}
+	n, _ = NewMockNetwork(`docker network ls -q`)
+	if output, _ := engine.ManageNetwork(&n); output != "bridge\nhost\nnetname\nnone\n" {
+		t.Fatalf("unexpect output of network ls: %q", output)
+	}
+}
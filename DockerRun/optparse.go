@@ -0,0 +1,115 @@
+package DockerRun
+
+import "strings"
+
+//FlagSet is the flags that can be recognized by a docker subcommand
+type FlagSet struct {
+	Short []string //the flag start with '-', such as -p -v -i
+	Long  []string //the flag start with '--', such as --volume, --link
+	NoArg []string //the flag attach with no arguments, such as -d, -i, --rm
+}
+
+//optionHandler setting up the property of a model according to the flags and arguments
+type optionHandler interface {
+	HandleFlag(flag string) error
+	HandleArgument(flag, arg string) error
+}
+
+//explain the options start from cmd[nowAt] until the first element that is not a flag,
+//return the index of that element and the fall reason or a empty message if all the options are accpeted
+func parseOptions(cmd []string, nowAt int, flagSet FlagSet, handler optionHandler) (int, Message) {
+	var err error
+	nowAt--
+	for {
+		nowAt++
+		if nowAt >= len(cmd) {
+			break
+		}
+		tflag := cmd[nowAt]
+		arg := ""
+		if strings.HasPrefix(tflag, "--") { //scuh as --rm --volume
+			flag := strings.TrimLeft(tflag, "--")
+			if index := strings.Index(flag, "="); index > 0 { //have a '=', such as --volume=test --rm=true
+				if index+1 == len(flag) { //no argument following '=', such as 'rm='
+					return nowAt, msg("flag.unexpect", tflag)
+				}
+				arg = flag[index+1:]
+				flag = flag[0:index]
+			}
+			if !findInArray(flagSet.Long, flag) {
+				return nowAt, msg("flag.unknown", tflag)
+			}
+			if findInArray(flagSet.NoArg, flag) { //don't need argument by default, such as --rm --tty
+				if arg == "" || arg == "true" {
+					err = handler.HandleFlag(flag)
+					if err != nil {
+						return nowAt, toMessage(err)
+					}
+				} else if arg != "false" {
+					return nowAt, msg("flag.unexpect_arg", flag, arg)
+				}
+			} else { //need a argument, such as --name
+				if arg != "" { //--name=hello
+					err = handler.HandleArgument(flag, arg)
+				} else { //--name hello
+					nowAt++
+					if len(cmd) <= nowAt {
+						return nowAt, msg("flag.no_arg_long", tflag)
+					}
+					err = handler.HandleArgument(flag, cmd[nowAt])
+				}
+				if err != nil {
+					return nowAt, toMessage(err)
+				}
+			}
+		} else if strings.HasPrefix(tflag, "-") { //such as -p -d
+			flags := strings.TrimLeft(tflag, "-")
+			for i := 0; i < len(flags); i++ {
+				arg := ""
+				flag := flags[i : i+1]
+				if !findInArray(flagSet.Short, flag) {
+					return nowAt, msg("flag.unknown_short", flag, flags[i+1:])
+				}
+				if findInArray(flagSet.NoArg, flag) { //do not have argument by default, like -p -t
+					if i+2 < len(flags) && flags[i+1] == '=' { //-t=true
+						arg = flags[i+2:]
+						i = len(flags)
+					}
+					if arg == "" || arg == "true" {
+						err = handler.HandleFlag(flag)
+						if err != nil {
+							return nowAt, toMessage(err)
+						}
+					} else if arg != "false" {
+						return nowAt, msg("flag.unexpect_short_arg", flag, arg)
+					}
+				} else {
+					if i+1 < len(flags) { //such as -ip8080:8080 or -ip=8080:8080
+						arg = trimStr(flags[i+1:])
+						if strings.HasPrefix(arg, "=") {
+							arg = arg[1:]
+						}
+						err = handler.HandleArgument(flag, arg)
+						if err != nil {
+							return nowAt, toMessage(err)
+						}
+						break
+					} else { //such as -ip 8080:8080
+						nowAt++
+						if nowAt >= len(cmd) {
+							return nowAt, msg("flag.no_arg_short", flag)
+						}
+						arg = cmd[nowAt]
+						err = handler.HandleArgument(flag, arg)
+						if err != nil {
+							return nowAt, toMessage(err)
+						}
+					}
+				}
+			}
+		} else { //not a flag
+			break
+		}
+	}
+	return nowAt, Message{}
+}
@@ -0,0 +1,210 @@
+package DockerRun
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+//the actions of a policy rule
+const (
+	PolicyAllow = "allow"
+	PolicyDeny  = "deny"
+)
+
+//PolicyFields is the fields of MockContainer that can be used in a policy rule
+var PolicyFields = []string{"Privileged", "VolumeSource", "Pid", "CapAdd", "Device", "NetWork", "Images", "UserNS", "User"}
+
+//PolicyRule allow or deny a container if a value of its field match the rule,
+//a value match the rule if it match one of the Values (the pattern of path.Match such as nginx:*, and * match all)
+//or it is under one of the Prefixes (such as /etc match /etc/passwd), a deny rule also match the paths that contain
+//one of the Prefixes (such as /var match /var/run/docker.sock), the Message can be a message id in the catalog
+type PolicyRule struct {
+	ID       string
+	Action   string
+	Field    string
+	Values   []string
+	Prefixes []string
+	Message  string
+}
+
+//Policy is a list of rules, the first rule that match a value decide whether it is allowed,
+//a value that match no rules is allowed
+type Policy struct {
+	Name  string
+	Rules []PolicyRule
+}
+
+//Violation is a value of container that denied by a rule of policy
+type Violation struct {
+	RuleID  string
+	Field   string
+	Value   string
+	Message string
+	message Message
+}
+
+//return the violation in the form of [SEC001] message
+func (this Violation) String() string {
+	return this.reason().String()
+}
+
+//return the violation as a message that can be rendered in any locale
+func (this Violation) reason() Message {
+	return msg("policy.violation", this.RuleID, this.message)
+}
+
+//return the policy that block the options that can control the host, it can be extended by exercise
+func DefaultSecurityPolicy() *Policy {
+	return &Policy{
+		Name: "default",
+		Rules: []PolicyRule{
+			{ID: "SEC001", Action: PolicyDeny, Field: "Privileged", Values: []string{"true"}, Message: "policy.privileged"},
+			//the host root contain the docker socket too, so it is checked first
+			{ID: "SEC003", Action: PolicyDeny, Field: "VolumeSource", Values: []string{"/"}, Message: "policy.host_root"},
+			{ID: "SEC002", Action: PolicyDeny, Field: "VolumeSource", Prefixes: []string{"/var/run/docker.sock", "/run/docker.sock"}, Message: "policy.docker_sock"},
+			{ID: "SEC004", Action: PolicyDeny, Field: "Pid", Values: []string{"host"}, Message: "policy.pid_host"},
+			{ID: "SEC005", Action: PolicyDeny, Field: "CapAdd", Values: []string{"ALL", "SYS_ADMIN", "SYS_PTRACE", "SYS_MODULE", "NET_ADMIN"}, Message: "policy.capability"},
+			{ID: "SEC006", Action: PolicyDeny, Field: "Device", Values: []string{"*"}, Message: "policy.device"},
+			{ID: "SEC007", Action: PolicyDeny, Field: "NetWork", Values: []string{"host"}, Message: "policy.network_host"},
+		},
+	}
+}
+
+//return a new policy that check the rules before the rules of this policy, so that an exercise can allow or deny more than the course
+func (this *Policy) With(rules ...PolicyRule) *Policy {
+	policy := &Policy{Name: this.Name}
+	policy.Rules = append(policy.Rules, rules...)
+	policy.Rules = append(policy.Rules, this.Rules...)
+	return policy
+}
+
+//evaluate the policy on a container, return the violations in the order of fields and values
+func (this *Policy) Evaluate(ctr *MockContainer) []Violation {
+	violations := []Violation{}
+	for _, field := range PolicyFields {
+		for _, value := range policyValues(ctr, field) {
+			for _, rule := range this.Rules {
+				if rule.Field != field || !rule.match(value) {
+					continue
+				}
+				if rule.Action == PolicyDeny {
+					message := textMessage(rule.Message)
+					if rule.Message == "" {
+						message = msg("policy.not_allowed", field, value)
+					}
+					violations = append(violations, Violation{rule.ID, field, value, message.String(), message})
+				}
+				break
+			}
+		}
+	}
+	return violations
+}
+
+//check if the value match the rule, a path that contain a denied prefix is denied as well
+func (this *PolicyRule) match(value string) bool {
+	for _, pattern := range this.Values {
+		if ok, _ := path.Match(pattern, value); ok || pattern == "*" {
+			return true
+		}
+	}
+	for _, prefix := range this.Prefixes {
+		prefix = strings.TrimRight(prefix, "/")
+		if value == prefix || strings.HasPrefix(value, prefix+"/") {
+			return true
+		}
+		if this.Action == PolicyDeny && strings.HasPrefix(value, "/") && (value == "/" || strings.HasPrefix(prefix, value+"/")) {
+			return true
+		}
+	}
+	return false
+}
+
+//return the values of a field of the container that checked by policy
+func policyValues(ctr *MockContainer, field string) []string {
+	switch field {
+	case "Privileged":
+		if ctr.IsPrivileged {
+			return []string{"true"}
+		}
+	case "VolumeSource":
+		values := []string{}
+		for _, source := range sortedKeys(ctr.Volume) {
+			if strings.HasPrefix(source, "/") {
+				source = path.Clean(source)
+			}
+			values = append(values, source)
+		}
+		return values
+	case "Pid":
+		return nonEmpty(ctr.Pid)
+	case "CapAdd":
+		return ctr.CapAdd
+	case "Device":
+		values := []string{}
+		for _, d := range ctr.Device {
+			values = append(values, strings.Split(d, ":")[0])
+		}
+		return values
+	case "NetWork":
+		return nonEmpty(ctr.NetWork)
+	case "Images":
+		return nonEmpty(ctr.Images)
+	case "UserNS":
+		return nonEmpty(ctr.UserNS)
+	case "User":
+		return nonEmpty(ctr.User)
+	}
+	return nil
+}
+
+//return a list that only have the value if it is not empty
+func nonEmpty(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
+
+//read a policy from yaml, it have a name and a list of rules with id, action, field, values, prefixes and message
+func LoadPolicy(text string) (*Policy, error) {
+	doc, err := parseYAML(text)
+	if err != nil {
+		return nil, err
+	}
+	root, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("policy should be a mapping")
+	}
+	policy := &Policy{Name: composeString(root["name"])}
+	items, ok := root["rules"].([]interface{})
+	if !ok && root["rules"] != nil {
+		return nil, fmt.Errorf("rules of policy should be a list")
+	}
+	for i, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("rule %d should be a mapping", i+1)
+		}
+		rule := PolicyRule{
+			ID:       composeString(m["id"]),
+			Action:   composeString(m["action"]),
+			Field:    composeString(m["field"]),
+			Values:   composeList(m["values"]),
+			Prefixes: composeList(m["prefixes"]),
+			Message:  composeString(m["message"]),
+		}
+		if rule.ID == "" {
+			return nil, fmt.Errorf("rule %d have no id", i+1)
+		}
+		if rule.Action != PolicyAllow && rule.Action != PolicyDeny {
+			return nil, fmt.Errorf("rule %s: unknown action %s", rule.ID, rule.Action)
+		}
+		if !findInArray(PolicyFields, rule.Field) {
+			return nil, fmt.Errorf("rule %s: unknown field %s", rule.ID, rule.Field)
+		}
+		policy.Rules = append(policy.Rules, rule)
+	}
+	return policy, nil
+}
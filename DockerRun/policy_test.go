@@ -0,0 +1,56 @@
+package DockerRun
+
+import "testing"
+
+func TestSecurityPolicy(t *testing.T) {
+	expect := map[string]string{
+		`docker run --privileged mysql`:                                                "SEC001",
+		`docker run -v /var/run/docker.sock:/var/run/docker.sock mysql`:                "SEC002",
+		`docker run -v /run/docker.sock/:/docker.sock mysql`:                           "SEC002",
+		`docker run -v /:/host mysql`:                                                  "SEC003",
+		`docker run -v /var/run:/x mysql`:                                              "SEC002",
+		`docker run -v /run:/x mysql`:                                                  "SEC002",
+		`docker run -v /var:/x mysql`:                                                  "SEC002",
+		`docker run -v /var/./run/../run/:/x mysql`:                                    "SEC002",
+		`docker run --mount type=bind,src=/var/run,dst=/r mysql`:                       "SEC002",
+		`docker run -v /var/lib/mysql:/var/lib/mysql -v /running:/x mysql`:             "",
+		`docker run --pid host mysql`:                                                  "SEC004",
+		`docker run --cap-add cap_sys_admin mysql`:                                     "SEC005",
+		`docker run --device /dev/sda:/dev/xvdc mysql`:                                 "SEC006",
+		`docker run --network host mysql`:                                              "SEC007",
+		`docker run --cap-add CHOWN -v /home/user/data:/data mysql`:                    "",
+		`docker run -v $PWD/var/run/docker.sock:/docker.sock --pid=container:db mysql`: "",
+	}
+	policy := DefaultSecurityPolicy()
+	for cmd, id := range expect {
+		ctr, err := NewMockContainer(cmd)
+		if err != nil {
+			t.Fatalf("Create container fail at command %s : %v", cmd, err)
+		}
+		violations := policy.Evaluate(&ctr)
+		if (id == "" && len(violations) != 0) || (id != "" && (len(violations) != 1 || violations[0].RuleID != id)) {
+			t.Fatalf("command '%s' expect violation '%s' but got: %v", cmd, id, violations)
+		}
+	}
+	course, err := LoadPolicy("name: course\nrules:\n  - id: C001\n    action: deny\n    field: Images\n    values: [\"ubuntu:*\"]\n    message: use alpine instead\n")
+	if err != nil {
+		t.Fatalf("load policy fail: %v", err)
+	}
+	exercise := Exercise{Answer: `docker run -v /var/run/docker.sock:/var/run/docker.sock portainer/portainer`}
+	exercise.Policy = DefaultSecurityPolicy().With(course.Rules...).With(PolicyRule{ID: "EX001", Action: PolicyAllow, Field: "VolumeSource", Prefixes: []string{"/var/run/docker.sock"}})
+	if res := exercise.Judge(`docker run -v /var/run/docker.sock:/var/run/docker.sock portainer/portainer`); res != "" {
+		t.Fatalf("the docker socket should be allowed by the exercise: %s", res)
+	}
+	if res := exercise.Judge(`docker run --privileged -v /var/run/docker.sock:/var/run/docker.sock portainer/portainer`); res != "[SEC001] --privileged is not allowed" {
+		t.Fatalf("--privileged should be blocked but got: %s", res)
+	}
+	ctr, _ := NewMockContainer(`docker run ubuntu:jammy`)
+	if v := exercise.Policy.Evaluate(&ctr); len(v) != 1 || v[0].String() != "[C001] use alpine instead" {
+		t.Fatalf("ubuntu should be blocked by the course but got: %v", v)
+	}
+	for _, text := range []string{"rules:\n  - id: X\n    action: block\n    field: Pid\n", "rules:\n  - action: deny\n    field: Pid\n", "rules:\n  - id: X\n    action: deny\n    field: Memory\n"} {
+		if _, err := LoadPolicy(text); err == nil {
+			t.Fatalf("worng policy pass: %s", text)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package DockerRun
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+//the formats can be used by Render()
+const (
+	FormatText     = "text"
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+	FormatMarkdown = "markdown"
+)
+
+//renderField is a property of container that have been set, Value is one of string, int, bool, []string, map[string]string
+type renderField struct {
+	Name  string
+	Value interface{}
+}
+
+//return the property that have been changed of a container in a fixed order
+func (this *MockContainer) renderFields() []renderField {
+	fields := []renderField{}
+	addStr := func(name, value string) {
+		if value != "" {
+			fields = append(fields, renderField{name, value})
+		}
+	}
+	addBool := func(name string, value bool) {
+		if value {
+			fields = append(fields, renderField{name, value})
+		}
+	}
+	addList := func(name string, value []string) {
+		if len(value) > 0 {
+			fields = append(fields, renderField{name, value})
+		}
+	}
+	addMap := func(name string, value map[string]string) {
+		if len(value) > 0 {
+			fields = append(fields, renderField{name, value})
+		}
+	}
+	addStr("Images", this.Images)
+	addStr("Command", this.Command)
+	addStr("HostName", this.HostName)
+	addStr("ContainerName", this.ContainerName)
+	addStr("User", this.User)
+	addStr("WorkDir", this.WorkDir)
+	addStr("NetWork", this.NetWork)
+	addStr("Restart", this.Restart)
+	addStr("CLI", this.CLI)
+	addStr("Pod", this.Pod)
+	addStr("UserNS", this.UserNS)
+	addStr("Platform", this.Platform)
+	addStr("Pull", this.Pull)
+	addStr("Gpus", this.Gpus)
+	addStr("KernelMemory", this.KernelMemory)
+	addStr("Pid", this.Pid)
+	addBool("IsRemove", this.IsRemove)
+	addBool("IsDetach", this.IsDetach)
+	addBool("IsTTY", this.IsTTY)
+	addBool("IsInteractive", this.IsInteractive)
+	addBool("IsPublishAll", this.IsPublishAll)
+	addBool("IsCreate", this.IsCreate)
+	addBool("IsPrivileged", this.IsPrivileged)
+	addList("Arg", this.Arg)
+	addList("Attach", this.Attach)
+	addList("Link", this.Link)
+	addList("Mount", this.Mount)
+	addList("CapAdd", this.CapAdd)
+	addList("CapDrop", this.CapDrop)
+	addList("Device", this.Device)
+	addList("Label", this.Label)
+	addList("EnvFromHost", this.EnvFromHost)
+	addMap("Port", this.Port)
+	addMap("Volume", this.Volume)
+	addMap("VolumeOption", this.VolumeOption)
+	addMap("Env", this.Env)
+	if this.CpuShare != 0 {
+		fields = append(fields, renderField{"CpuShare", this.CpuShare})
+	}
+	if this.Memory != 0 {
+		fields = append(fields, renderField{"Memory", this.Memory})
+	}
+	return fields
+}
+
+//write the property that have been changed of a container to w in the given format
+//return error if the format is unknown or fail to write
+func (this *MockContainer) Render(w io.Writer, format string) error {
+	switch strings.ToLower(format) {
+	case FormatText, "":
+		return this.renderText(w)
+	case FormatJSON:
+		return this.renderJSON(w)
+	case FormatYAML:
+		return this.renderYAML(w)
+	case FormatMarkdown, "md":
+		return this.renderMarkdown(w)
+	}
+	return fmt.Errorf("Unknown render format: %s", format)
+}
+
+//execute a user supplied text/template with the container as data, such as '{{.Images}} {{join .Arg " "}}'
+func (this *MockContainer) RenderTemplate(w io.Writer, tmpl string) error {
+	t, err := template.New("container").Funcs(template.FuncMap{
+		"join": strings.Join,
+		"keys": sortedKeys,
+	}).Parse(tmpl)
+	if err != nil {
+		return err
+	}
+	return t.Execute(w, this)
+}
+
+//the same format that used by Printf()
+func (this *MockContainer) renderText(w io.Writer) error {
+	for _, f := range this.renderFields() {
+		var err error
+		if f.Name == "Memory" {
+			_, err = fmt.Fprintln(w, "Memory  :  ", f.Value)
+		} else {
+			_, err = fmt.Fprintf(w, "%s  :  %v \n", f.Name, f.Value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//an object with the same fields in the same order as the other formats, it can be decoded into a MockContainer
+func (this *MockContainer) renderJSON(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("{")
+	for i, f := range this.renderFields() {
+		value, err := json.MarshalIndent(f.Value, "  ", "  ")
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString(fmt.Sprintf("\n  %q: %s", f.Name, value))
+	}
+	if sb.Len() > 1 {
+		sb.WriteString("\n")
+	}
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func (this *MockContainer) renderYAML(w io.Writer) error {
+	var sb strings.Builder
+	for _, f := range this.renderFields() {
+		writeYAMLValue(&sb, f.Name, f.Value, 0)
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func (this *MockContainer) renderMarkdown(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("| Property | Value |\n")
+	sb.WriteString("| --- | --- |\n")
+	for _, f := range this.renderFields() {
+		value := ""
+		switch v := f.Value.(type) {
+		case []string:
+			value = "`" + strings.Join(v, "` `") + "`"
+		case map[string]string:
+			pairs := []string{}
+			for _, k := range sortedKeys(v) {
+				pairs = append(pairs, fmt.Sprintf("`%s:%s`", k, v[k]))
+			}
+			value = strings.Join(pairs, " ")
+		default:
+			value = fmt.Sprintf("`%v`", v)
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s |\n", f.Name, strings.Replace(value, "|", "\\|", -1)))
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+//return the keys of a map in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,50 @@
+package DockerRun
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	ctr, err := NewMockContainer(`docker run --rm -it -p 8080:80 -v $PWD:/workspace --name web images:latest sh -c pwd`)
+	if err != nil {
+		t.Fatalf("create container fail: %v", err)
+	}
+	expect := map[string]string{
+		FormatText:     "Port  :  map[8080:80] \n",
+		FormatYAML:     "Port:\n  \"8080\": \"80\"\n",
+		FormatMarkdown: "| Arg | `-c` `pwd` |\n",
+	}
+	for format, want := range expect {
+		var buf bytes.Buffer
+		if err := ctr.Render(&buf, format); err != nil {
+			t.Fatalf("render %s fail: %v", format, err)
+		}
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("render %s expect contain %q but got:\n%s", format, want, buf.String())
+		}
+	}
+	var buf bytes.Buffer
+	if err := ctr.Render(&buf, FormatJSON); err != nil {
+		t.Fatalf("render json fail: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "{\n  \"Images\": \"images:latest\",\n  \"Command\": \"sh\",") || strings.Contains(buf.String(), "Warnings") || strings.Contains(buf.String(), "IsDetach") {
+		t.Fatalf("json should only contain the fields that have been set:\n%s", buf.String())
+	}
+	var decoded MockContainer
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || Judge(&decoded, &ctr) != "" {
+		t.Fatalf("json can not be decoded into the same container: %v", err)
+	}
+	if ctr.Render(&buf, "xml") == nil {
+		t.Fatalf("unknown format should return error")
+	}
+	buf.Reset()
+	if err := ctr.RenderTemplate(&buf, `{{.Images}} {{join .Arg ","}}{{range keys .Volume}} {{.}}{{end}}`); err != nil {
+		t.Fatalf("render template fail: %v", err)
+	}
+	if buf.String() != "images:latest -c,pwd $PWD" {
+		t.Fatalf("unexpect template output: %s", buf.String())
+	}
+}
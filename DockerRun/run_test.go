@@ -124,3 +124,18 @@ func TestChecking2(t *testing.T) {
 		}
 	}
 }
+
+func TestEnvArgument(t *testing.T) {
+	ctr, err := NewMockContainer(`docker run -e MODE=prod -e HOME --env=URL=a=b nginx`)
+	if err != nil {
+		t.Fatalf("Create container fail: %v", err)
+	}
+	if len(ctr.Env) != 2 || ctr.Env["MODE"] != "prod" || ctr.Env["URL"] != "a=b" || len(ctr.EnvFromHost) != 1 || ctr.EnvFromHost[0] != "HOME" {
+		t.Fatalf("worng env: %v %v", ctr.Env, ctr.EnvFromHost)
+	}
+	for _, cmd := range []string{`docker run -e =prod nginx`, `docker run -e "" nginx`} {
+		if _, err := NewMockContainer(cmd); err == nil {
+			t.Fatalf("worng command %s pass!", cmd)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package DockerRun
+
+import (
+	"regexp"
+	"strings"
+)
+
+//the modes can be used by JudgeSequence() to compare a series of commands
+const (
+	SequenceExact       = "exact"       //the same commands in the same order
+	SequenceSubsequence = "subsequence" //the commands of answer appear in order, extra commands are allowed
+	SequenceFinalState  = "final-state" //the engine have the same state after all commands are applied
+)
+
+//a redirection such as '2>&1', '>>out.log' or '< input', the operator alone take the next word as its target
+var redirectionReg = regexp.MustCompile(`^([0-9]*|&)(>>?|<)`)
+
+//a command of a shell list and the operator after it, such as '&&', '||', ';' or a newline
+type shellCommand struct {
+	line     string
+	operator string
+}
+
+//split a shell script into an ordered list of docker commands,
+//the commands can be joined by '&&', '||', ';' or newlines, the comments and '\' continuations are understood,
+//the commands that not start with docker (or sudo docker) such as 'set -e' are ignored,
+//each command is tokenized like shell and the redirections such as '2>&1' are removed
+func SplitScript(script string) ([]string, error) {
+	commands, err := splitShellList(script)
+	if err != nil {
+		return nil, err
+	}
+	dockerCmds := []string{}
+	for _, command := range commands {
+		words, err := splitWords(command.line, true)
+		if err != nil {
+			return nil, err
+		}
+		if words, err = dropRedirections(words); err != nil {
+			return nil, err
+		}
+		cmd := strings.Join(words, " ")
+		if splitCommand(cmd)[0] == "docker" {
+			dockerCmds = append(dockerCmds, cmd)
+		}
+	}
+	return dockerCmds, nil
+}
+
+//split a shell script into the commands joined by the operators, the operators in quotes are part of the command
+func splitShellList(script string) ([]shellCommand, error) {
+	commands := []shellCommand{}
+	pending := "" //the operator that still waiting for a command, such as 'a &&' at the end of a line
+	var sb strings.Builder
+	flush := func(op string) error {
+		cmd := strings.TrimSpace(sb.String())
+		sb.Reset()
+		if cmd == "" {
+			if op == "\n" { //a blank line
+				return nil
+			}
+			return msg("shell.syntax", op)
+		}
+		pending = ""
+		if op == "&&" || op == "||" {
+			pending = op
+		}
+		commands = append(commands, shellCommand{cmd, op})
+		return nil
+	}
+	var quote byte
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		next := byte(0)
+		if i+1 < len(script) {
+			next = script[i+1]
+		}
+		if quote != 0 { //the operators in quotes are part of the argument
+			sb.WriteByte(c)
+			if c == '\\' && quote == '"' && next != 0 {
+				sb.WriteByte(next)
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		var err error
+		switch {
+		case c == '\\' && next == '\n':
+			i++
+		case c == '\\' && next == '\r' && i+2 < len(script) && script[i+2] == '\n':
+			i += 2
+		case c == '\\' && next != 0:
+			sb.WriteByte(c)
+			sb.WriteByte(next)
+			i++
+		case c == '\'' || c == '"':
+			quote = c
+			sb.WriteByte(c)
+		case c == '#' && (sb.Len() == 0 || strings.HasSuffix(sb.String(), " ") || strings.HasSuffix(sb.String(), "\t")):
+			for i+1 < len(script) && script[i+1] != '\n' {
+				i++
+			}
+		case c == '\n':
+			err = flush("\n")
+		case c == ';':
+			err = flush(";")
+		case c == '&' && next == '&', c == '|' && next == '|':
+			err = flush(string([]byte{c, next}))
+			i++
+		case c == '|':
+			err = msg("shell.pipe", strings.TrimSpace(sb.String()))
+		case c == '&' && strings.HasSuffix(sb.String(), ">"): //a redirection such as 2>&1
+			sb.WriteByte(c)
+		case c == '&': //run in background, the same as ';' here
+			err = flush("&")
+		case c == '\r' || c == '\t':
+			sb.WriteByte(' ')
+		default:
+			sb.WriteByte(c)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if quote != 0 {
+		return nil, msg("shell.quote", string(quote))
+	}
+	if err := flush("\n"); err != nil {
+		return nil, err
+	}
+	if pending != "" {
+		return nil, msg("shell.eof", pending)
+	}
+	return commands, nil
+}
+
+//remove the redirections from the words of a command, they change nothing of the docker engine
+func dropRedirections(words []string) ([]string, error) {
+	result := []string{}
+	for i := 0; i < len(words); i++ {
+		operator := redirectionReg.FindString(words[i])
+		if operator == "" {
+			result = append(result, words[i])
+			continue
+		}
+		if operator == words[i] { //such as '> out.log'
+			if i+1 >= len(words) {
+				return nil, msg("shell.syntax", "newline")
+			}
+			i++
+		}
+	}
+	return result, nil
+}
+
+//split a command line into words like shell, a backslash escape the next character and the blanks in quotes are kept,
+//the quotes are removed unless keepQuotes is true, so that trimStr() can still tell a quoted argument
+func splitWords(line string, keepQuotes bool) ([]string, error) {
+	words := []string{}
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+	for _, c := range line {
+		switch {
+		case escaped:
+			escaped = false
+			if c == '\n' && quote == 0 { //a line continuation
+				continue
+			}
+			if keepQuotes {
+				word.WriteRune('\\')
+			}
+			word.WriteRune(c)
+			inWord = true
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				escaped = true
+				continue
+			}
+			if c == quote {
+				quote = 0
+				if !keepQuotes {
+					continue
+				}
+			}
+			word.WriteRune(c)
+		case c == '\'' || c == '"':
+			quote = c
+			inWord = true
+			if keepQuotes {
+				word.WriteRune(c)
+			}
+		case c == '\\':
+			escaped = true
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(c)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, msg("shell.quote", string(quote))
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+//split a command line into words like shell and remove the quotes
+func shellWords(line string) ([]string, error) {
+	return splitWords(line, false)
+}
+
+//judge if a series of docker commands is right by compared to the answer according to the mode,
+//return a string to describe the mistake or a null string if it is accepted
+func JudgeSequence(test, ans []string, mode string) string {
+	return judgeSequence(test, ans, mode).String()
+}
+
+//judge a series of docker commands, return the mistake or a empty message if it is accepted
+func judgeSequence(test, ans []string, mode string) Message {
+	switch mode {
+	case SequenceExact:
+		if len(test) != len(ans) {
+			return msg("sequence.number", len(ans), len(test))
+		}
+		for i := range ans {
+			if reason := judgeCommand(test[i], ans[i]); !reason.IsEmpty() {
+				return msg("sequence.command", i+1, reason)
+			}
+		}
+	case SequenceSubsequence:
+		nowAt := 0
+		for i, a := range ans {
+			for nowAt < len(test) && !judgeCommand(test[nowAt], a).IsEmpty() {
+				nowAt++
+			}
+			if nowAt >= len(test) {
+				return msg("sequence.missing", i+1, a)
+			}
+			nowAt++
+		}
+	case SequenceFinalState:
+		ansEngine := NewMockEngine()
+		if err := ansEngine.RunCommands(ans); err != nil {
+			return msg("exercise.answer", toMessage(err))
+		}
+		testEngine := NewMockEngine()
+		if err := testEngine.RunCommands(test); err != nil {
+			return toMessage(err)
+		}
+		return judgeEngine(testEngine, ansEngine)
+	default:
+		return msg("sequence.mode", mode)
+	}
+	return Message{}
+}
+
+//split the script of test and answer and judge them as a sequence,
+//return a string to describe the mistake or a null string if it is accepted
+func JudgeScript(test, ans string, mode string) string {
+	return judgeScript(test, ans, mode).String()
+}
+
+//split the script of test and answer and judge them, return the mistake or a empty message if it is accepted
+func judgeScript(test, ans string, mode string) Message {
+	ansCmds, err := SplitScript(ans)
+	if err != nil {
+		return msg("exercise.answer", toMessage(err))
+	}
+	testCmds, err := SplitScript(test)
+	if err != nil {
+		return toMessage(err)
+	}
+	return judgeSequence(testCmds, ansCmds, mode)
+}
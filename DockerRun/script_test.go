@@ -0,0 +1,64 @@
+package DockerRun
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitScript(t *testing.T) {
+	script := "#!/bin/bash\nset -e\n# create the network first\ndocker network create n && docker run -d \\\n  --network n --name db mysql # the database\n\ndocker exec db sh -c 'echo a && echo b'; docker logs db 2>&1 ||\n  docker ps -a"
+	expect := []string{
+		`docker network create n`,
+		`docker run -d --network n --name db mysql`,
+		`docker exec db sh -c 'echo a && echo b'`,
+		`docker logs db`,
+		`docker ps -a`,
+	}
+	cmds, err := SplitScript(script)
+	if err != nil {
+		t.Fatalf("split script fail: %v", err)
+	}
+	if strings.Join(cmds, "\n") != strings.Join(expect, "\n") {
+		t.Fatalf("unexpect commands: %q", cmds)
+	}
+	cmds, err = SplitScript(`docker run --name web alpine sh -c "echo a b" > out.log 2>&1; docker logs web 2> /dev/null`)
+	if err != nil || strings.Join(cmds, "\n") != "docker run --name web alpine sh -c \"echo a b\"\ndocker logs web" {
+		t.Fatalf("unexpect commands: %q, %v", cmds, err)
+	}
+	if words := splitCommand(cmds[0]); len(words) != 8 || words[7] != `"echo a b"` {
+		t.Fatalf("the quoted argument is splited: %q", words)
+	}
+	for _, script := range []string{`docker ps >`, `docker ps &&`, `&& docker ps`, `docker ps;; docker ps`, `docker run 'mysql`, `docker ps | grep db`} {
+		if _, err := SplitScript(script); err == nil {
+			t.Fatalf("worng script %s pass!", script)
+		}
+	}
+}
+
+func TestJudgeSequence(t *testing.T) {
+	expect := map[string]map[string][3]bool{ //the answer, the test and the result of exact, subsequence and final-state
+		"docker network create net1\ndocker run -d --network net1 --name db mysql": {
+			`docker network create net1 && docker run -d --network=net1 --name db mysql`:                               {true, true, true},
+			`docker network create net1; docker pull mysql; docker run -d --network net1 --name db mysql`:              {false, true, true},
+			`docker run -d --network net1 --name db mysql; docker network create net1`:                                 {false, false, false},
+			`docker network create net1 && docker create --network net1 --name db mysql && docker start db`:            {false, false, true},
+			`docker network create net1 && docker run -d --network net1 --name db mysql && docker stop db`:             {false, true, false},
+			`docker network create net1 && docker run -d --name db mysql && docker network connect net1 db`:            {false, true, false},
+			`docker network create net1 && docker network create net1 && docker run -d --network net1 --name db mysql`: {false, true, false},
+		},
+		`docker volume create data && docker run --name web -v data:/data alpine sh -c "echo a b > /data/f"`: {
+			`docker volume create data && docker run --name web -v data:/data alpine sh -c "echo a b > /data/f" 2>&1`: {true, true, true},
+			`docker volume create data && docker run --name web -v data:/data alpine sh -c "echo a c > /data/f"`:      {false, false, false},
+		},
+	}
+	for ans, tests := range expect {
+		for script, results := range tests {
+			for i, mode := range []string{SequenceExact, SequenceSubsequence, SequenceFinalState} {
+				res := JudgeScript(script, ans, mode)
+				if (res == "") != results[i] {
+					t.Fatalf("script '%s' in mode %s expect %v but got: %s", script, mode, results[i], res)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package DockerRun
+
+import "strings"
+
+//check the combination of the options of a container after they are parsed,
+//return the errors for the options that are rejected by docker and the warnings for the options that have no effect,
+//the messages are the same as docker if it have one
+func (this *MockContainer) Validate() (errs []Message, warnings []Message) {
+	network := this.NetWork
+	if strings.HasPrefix(network, "container:") {
+		network = "container"
+	}
+	if this.IsRemove && this.Restart != "" && this.Restart != "no" {
+		errs = append(errs, msg("semantic.restart_rm"))
+	}
+	if this.IsDetach && len(this.Attach) > 0 {
+		errs = append(errs, msg("semantic.attach_detach"))
+	}
+	switch network {
+	case "host":
+		if len(this.Link) > 0 {
+			errs = append(errs, msg("semantic.network_links", "host"))
+		}
+		if len(this.Port) > 0 || this.IsPublishAll {
+			warnings = append(warnings, msg("semantic.ports_discarded", "host"))
+		}
+	case "none":
+		if len(this.Link) > 0 {
+			errs = append(errs, msg("semantic.network_links", "none"))
+		}
+		if len(this.Port) > 0 || this.IsPublishAll {
+			warnings = append(warnings, msg("semantic.ports_discarded", "none"))
+		}
+	case "container":
+		if len(this.Link) > 0 {
+			errs = append(errs, msg("semantic.container_links"))
+		}
+		if len(this.Port) > 0 || this.IsPublishAll {
+			errs = append(errs, msg("semantic.container_publish"))
+		}
+		if this.HostName != "" {
+			errs = append(errs, msg("semantic.container_hostname"))
+		}
+	}
+	if this.IsPublishAll && len(this.Port) == 0 && !findInArray([]string{"host", "none", "container"}, network) {
+		warnings = append(warnings, msg("semantic.publish_all", this.Images))
+	}
+	return errs, warnings
+}
+
+//run the semantic validation when the command is parsed, the warnings are recorded in the container,
+//return the first error or a empty message if it is accepted
+func (this *MockContainer) validate() Message {
+	errs, warnings := this.Validate()
+	for _, w := range warnings {
+		this.warn(w)
+	}
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return Message{}
+}
+
+//record a warning of the container if it is not recorded yet
+func (this *MockContainer) warn(warning Message) {
+	if !containMessage(this.Warnings, warning) {
+		this.Warnings = append(this.Warnings, warning)
+	}
+}
@@ -0,0 +1,39 @@
+package DockerRun
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	expect := map[string]string{
+		`docker run -d --rm --restart always mysql`:                   `Conflicting options: --restart and --rm`,
+		`docker run -d -a stdout mysql`:                               `Conflicting options: -a and -d`,
+		`docker run --network host --link db:db mysql`:                `host type networking can't be used with links`,
+		`docker run --network none --link db:db mysql`:                `none type networking can't be used with links`,
+		`docker run --network container:db -p 80:80 mysql`:            `port publishing and the container type network mode`,
+		`docker run --network container:db --hostname web mysql`:      `hostname and the network mode`,
+		`docker run -d --rm --restart no mysql`:                       ``,
+		`docker run -a stdout -a stderr --restart on-failure:3 mysql`: ``,
+	}
+	for cmd, msg := range expect {
+		_, err := NewMockContainer(cmd)
+		if (msg == "" && err != nil) || (msg != "" && (err == nil || !strings.Contains(err.Error(), msg))) {
+			t.Fatalf("command '%s' expect error '%s' but got: %v", cmd, msg, err)
+		}
+	}
+	warnings := map[string]string{
+		`docker run -d --network host -p 80:80 nginx`: `Published ports are discarded when using host network mode`,
+		`docker run -d -P nginx`:                      `-P only publishes the ports exposed by the image nginx:latest`,
+		`docker run -d -P -p 80:80 nginx`:             ``,
+	}
+	for cmd, msg := range warnings {
+		ctr, err := NewMockContainer(cmd)
+		if err != nil {
+			t.Fatalf("Create container fail at command %s : %v", cmd, err)
+		}
+		if (msg == "" && len(ctr.Warnings) != 0) || (msg != "" && (len(ctr.Warnings) != 1 || !strings.Contains(ctr.Warnings[0].String(), msg))) {
+			t.Fatalf("command '%s' expect warning '%s' but got: %v", cmd, msg, ctr.Warnings)
+		}
+	}
+}
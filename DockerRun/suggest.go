@@ -0,0 +1,301 @@
+package DockerRun
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+//the arguments of the flags that can be guessed when they are misspelled
+var suggestValues = map[string][]string{
+	"restart": {"no", "always", "unless-stopped", "on-failure"},
+	"a":       {"stdin", "stdout", "stderr"},
+	"attach":  {"stdin", "stdout", "stderr"},
+	"pull":    {"always", "missing", "never"},
+	"network": {"bridge", "host", "none"},
+}
+
+//a container port or a range of ports without the host port, such as 8080, 8000-8010 or 53/udp,
+//docker publish them on a random host port but the parser only accept hostPort:containerPort
+var portSpecReg = regexp.MustCompile(`^\d+(-\d+)?(/\w+)?$`)
+
+//Suggestion is the guess of what the student want to write in a docker run command
+type Suggestion struct {
+	Hints     []string //such as 'unknown flag --rmv, did you mean --rm?'
+	Corrected string   //the corrected command, it is empty if it can't be corrected
+}
+
+//find the misspelled flags and arguments and the common mistakes in a docker run command,
+//such as --rmv, -name, -it--hostname, --rm-it and -P 8080:80, and propose a corrected command
+func Suggest(dockerCmd string) Suggestion {
+	hints, corrected := suggest(dockerCmd)
+	return Suggestion{defaultCatalog.RenderAll(LocaleEnglish, hints), corrected}
+}
+
+//find the mistakes of a docker run command, return the hints and the corrected command
+func suggest(dockerCmd string) ([]Message, string) {
+	hints, corrected := []Message{}, ""
+	cmd := splitCommand(dockerCmd)
+	stripped, reason := stripGlobalOptions(cmd, &ClientConfig{})
+	if !reason.IsEmpty() {
+		return nil, ""
+	}
+	start := runOptionStart(stripped) //both 'run' and 'container run' are understood like normalizeCommand()
+	if start < 0 {
+		return nil, ""
+	}
+	start += len(cmd) - len(stripped) //the global options are kept as they are
+	flagSet := runFlagsOf(cmd[0])
+	fixed := append([]string{}, cmd[:start]...)
+	changed := false
+	i := start
+	for ; i < len(cmd) && strings.HasPrefix(cmd[i], "-") && cmd[i] != "-"; i++ {
+		tokens, tokenHints := fixFlagToken(cmd[i], flagSet)
+		hints = append(hints, tokenHints...)
+		if len(tokens) != 1 || tokens[0] != cmd[i] {
+			changed = true
+		}
+		last := tokens[len(tokens)-1]
+		flag := needArgument(last, flagSet)
+		next := ""
+		if i+1 < len(cmd) {
+			next = cmd[i+1]
+		}
+		switch {
+		case strings.HasSuffix(last, "P") && !strings.HasPrefix(last, "--") && isPortArg(trimStr(next)):
+			last = last[:len(last)-1] + "p"
+			flag = "p"
+			hints = append(hints, msg("suggest.publish_all_arg", next))
+			changed = true
+		case flag == "p" && portSpecReg.MatchString(trimStr(next)):
+			hints = append(hints, msg("suggest.publish_host_port", trimStr(next)))
+			if _, err := strconv.Atoi(trimStr(next)); err == nil { //the same port on the host is the most likely guess
+				cmd[i+1] = trimStr(next) + ":" + trimStr(next)
+				changed = true
+			}
+		case flag == "p" && next != "" && !strings.Contains(next, ":") && !portSpecReg.MatchString(trimStr(next)) && isImagesName(next):
+			last = last[:len(last)-1] + "P"
+			flag = ""
+			hints = append(hints, msg("suggest.publish_no_arg"))
+			changed = true
+		}
+		tokens[len(tokens)-1] = last
+		fixed = append(fixed, tokens...)
+		if flag != "" && i+1 < len(cmd) {
+			i++
+			value, hint := fixFlagValue(flag, cmd[i])
+			if !hint.IsEmpty() {
+				hints = append(hints, hint)
+				changed = true
+			}
+			fixed = append(fixed, value)
+		}
+	}
+	fixed = append(fixed, cmd[i:]...)
+	if changed {
+		if _, err := NewMockContainer(strings.Join(fixed, " ")); err == nil {
+			corrected = strings.Join(fixed, " ")
+		}
+	}
+	return hints, corrected
+}
+
+//find the mistakes of a command that can only be known by compared to the answer, such as the swapped host and container port
+func SuggestByAnswer(test, ans *MockContainer) []string {
+	return defaultCatalog.RenderAll(LocaleEnglish, suggestByAnswer(test, ans))
+}
+
+//find the mistakes of a command by compared to the answer, return the hints
+func suggestByAnswer(test, ans *MockContainer) []Message {
+	hints := []Message{}
+	for _, hostPort := range sortedKeys(test.Port) {
+		conPort := test.Port[hostPort]
+		if _, have := ans.Port[hostPort]; !have && ans.Port[conPort] == hostPort {
+			hints = append(hints, msg("suggest.swapped_port", conPort, hostPort))
+		}
+	}
+	for _, source := range sortedKeys(test.Volume) {
+		target := test.Volume[source]
+		if _, have := ans.Volume[source]; !have && ans.Volume[target] == source {
+			hints = append(hints, msg("suggest.swapped_volume", target, source))
+		}
+	}
+	return hints
+}
+
+//return the index of the first option of a docker run command, or -1 if it is not a run command
+func runOptionStart(cmd []string) int {
+	if len(cmd) < 2 || !findInArray(SupportedCLIs, cmd[0]) {
+		return -1
+	}
+	if cmd[1] == "run" || cmd[1] == "create" {
+		return 2
+	}
+	if len(cmd) > 2 && cmd[1] == "container" && (cmd[2] == "run" || cmd[2] == "create") {
+		return 3
+	}
+	return -1
+}
+
+//fix a flag token, return the tokens that should replace it and the hints
+func fixFlagToken(token string, flagSet FlagSet) ([]string, []Message) {
+	if strings.HasPrefix(token, "--") {
+		name, value := token[2:], ""
+		if index := strings.Index(name, "="); index > 0 {
+			name, value = name[:index], name[index:]
+		}
+		if findInArray(flagSet.Long, name) {
+			return []string{token}, nil
+		}
+		for k := 1; k < len(name); k++ { //missing space such as --rm-it
+			left, right := name[:k], name[k:]
+			if strings.HasPrefix(right, "-") && findInArray(flagSet.NoArg, left) && findInArray(flagSet.Long, left) && isShortGroup(right[1:]+value, flagSet) {
+				return []string{"--" + left, right + value}, []Message{msg("suggest.missing_space_long", token, left, right+value)}
+			}
+		}
+		if closest := closestWord(name, flagSet.Long); closest != "" {
+			return []string{"--" + closest + value}, []Message{msg("suggest.closest_long", name, closest)}
+		}
+		return []string{token}, []Message{msg("suggest.unknown_long", name)}
+	}
+	body := token[1:]
+	if index := strings.Index(body, "--"); index > 0 { //missing space such as -it--hostname
+		left, leftHints := fixFlagToken("-"+body[:index], flagSet)
+		right, rightHints := fixFlagToken(body[index:], flagSet)
+		hints := []Message{msg("suggest.missing_space_short", token, body[:index], body[index:])}
+		return append(left, right...), append(append(hints, leftHints...), rightHints...)
+	}
+	if isShortGroup(body, flagSet) {
+		return []string{token}, nil
+	}
+	name, value := body, ""
+	if index := strings.Index(body, "="); index > 0 {
+		name, value = body[:index], body[index:]
+	}
+	if findInArray(flagSet.Long, name) {
+		return []string{"--" + name + value}, []Message{msg("suggest.two_dashes", name)}
+	}
+	if closest := closestWord(name, flagSet.Long); closest != "" && len(name) > 2 {
+		return []string{"--" + closest + value}, []Message{msg("suggest.closest_short", token, closest)}
+	}
+	return []string{token}, []Message{msg("suggest.unknown_short", token)}
+}
+
+//check if a group of short flags such as it or p8080:80 can be explained by the flag set
+func isShortGroup(group string, flagSet FlagSet) bool {
+	if group == "" {
+		return false
+	}
+	for i := 0; i < len(group); i++ {
+		flag := group[i : i+1]
+		if !findInArray(flagSet.Short, flag) {
+			return false
+		}
+		if !findInArray(flagSet.NoArg, flag) || (i+1 < len(group) && group[i+1] == '=') { //the rest is argument
+			return true
+		}
+	}
+	return true
+}
+
+//return the flag if a token is a flag that need a argument in the next token, such as --name or -dp
+func needArgument(token string, flagSet FlagSet) string {
+	if strings.HasPrefix(token, "--") {
+		name := token[2:]
+		if strings.Contains(name, "=") || findInArray(flagSet.NoArg, name) || !findInArray(flagSet.Long, name) {
+			return ""
+		}
+		return name
+	}
+	group := strings.TrimPrefix(token, "-")
+	for i := 0; i < len(group); i++ {
+		flag := group[i : i+1]
+		if !findInArray(flagSet.Short, flag) || (i+1 < len(group) && group[i+1] == '=') {
+			return ""
+		}
+		if !findInArray(flagSet.NoArg, flag) {
+			if i+1 == len(group) {
+				return flag
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+//fix the misspelled argument of a flag such as --restart alway, return the argument and a hint if it is changed
+func fixFlagValue(flag, value string) (string, Message) {
+	candidates, have := suggestValues[flag]
+	if !have || findInArray(candidates, trimStr(value)) {
+		return value, Message{}
+	}
+	if flag == "restart" && isRestartPolicy(trimStr(value)) {
+		return value, Message{}
+	}
+	if flag == "network" { //a user-defined network can have any name
+		if closest := closestWord(trimStr(value), candidates); closest != "" && closest != "host" && closest != "none" {
+			return value, Message{}
+		}
+	}
+	closest := closestWord(strings.SplitN(trimStr(value), ":", 2)[0], candidates)
+	if closest == "" {
+		return value, Message{}
+	}
+	if parts := strings.SplitN(trimStr(value), ":", 2); len(parts) == 2 { //such as on-failur:3
+		closest += ":" + parts[1]
+	}
+	return closest, msg("suggest.value", value, flag, closest)
+}
+
+//return the word that is the most similar to the given one, the distance must be small enough,
+//return empty string if no one is similar
+func closestWord(word string, candidates []string) string {
+	best, bestDistance := "", 0
+	for _, c := range candidates {
+		d := levenshtein(strings.ToLower(word), c)
+		if d > 0 && d <= maxDistance(c) && (best == "" || d < bestDistance) {
+			best, bestDistance = c, d
+		}
+	}
+	return best
+}
+
+//the max edit distance that a misspelled word can have, the short word should be nearer
+func maxDistance(word string) int {
+	if len(word) <= 3 {
+		return 1
+	}
+	if len(word) <= 6 {
+		return 2
+	}
+	return 3
+}
+
+//return the edit distance of two strings
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		now := make([]int, len(b)+1)
+		now[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			now[j] = minInt(minInt(prev[j]+1, now[j-1]+1), prev[j-1]+cost)
+		}
+		prev = now
+	}
+	return prev[len(b)]
+}
+
+//return the smaller one of two int
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,54 @@
+package DockerRun
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSuggest(t *testing.T) {
+	testCase := []struct {
+		cmd       string
+		hint      string
+		corrected string
+	}{
+		{`docker run --rmv -it ubuntu`, "did you mean --rm?", `docker run --rm -it ubuntu`},
+		{`docker run -d -name web nginx`, "did you mean --name?", `docker run -d --name web nginx`},
+		{`docker run -it--hostname box ubuntu`, "missing space", `docker run -it --hostname box ubuntu`},
+		{`docker run --rm-it ubuntu`, "missing space", `docker run --rm -it ubuntu`},
+		{`docker run -d -P 8080:80 nginx`, "use -p 8080:80", `docker run -d -p 8080:80 nginx`},
+		{`docker run -d -p nginx`, "use -P", `docker run -d -P nginx`},
+		{`docker run -d -p 8080 nginx`, "-p 8080 has no host port", `docker run -d -p 8080:8080 nginx`},
+		{`docker run -d -p 53/udp dns`, "use -p hostPort:containerPort", ""},
+		{`docker run -d -p 8000-8010 nginx`, "-p 8000-8010 has no host port", ""},
+		{`docker run -d --restart alway nginx`, "did you mean always?", `docker run -d --restart always nginx`},
+		{`docker run -d --hostnam box nginx`, "did you mean --hostname?", `docker run -d --hostname box nginx`},
+		{`docker -H tcp://lab:2375 run --rmv nginx`, "did you mean --rm?", `docker -H tcp://lab:2375 run --rm nginx`},
+		{`docker --context lab container run -d -p nginx`, "use -P", `docker --context lab container run -d -P nginx`},
+	}
+	for i, c := range testCase {
+		s := Suggest(c.cmd)
+		if !strings.Contains(strings.Join(s.Hints, "\n"), c.hint) {
+			t.Fatalf("worng hints at command %d : %v", i, s.Hints)
+		}
+		if s.Corrected != c.corrected {
+			t.Fatalf("worng correction at command %d : %s", i, s.Corrected)
+		}
+	}
+	for _, cmd := range []string{
+		`docker run -d --name web -p 8080:80 nginx`,
+		`docker run -it --rm --network mynet ubuntu`,
+	} {
+		if s := Suggest(cmd); len(s.Hints) > 0 || s.Corrected != "" {
+			t.Fatalf("suggestion for a right command %s : %v", cmd, s.Hints)
+		}
+	}
+}
+
+func TestSuggestByAnswer(t *testing.T) {
+	test, _ := NewMockContainer(`docker run -d -p 80:8080 -v /data:$PWD nginx`)
+	ans, _ := NewMockContainer(`docker run -d -p 8080:80 -v $PWD:/data nginx`)
+	hints := SuggestByAnswer(&test, &ans)
+	if len(hints) != 2 || !strings.Contains(hints[0], "-p 8080:80") || !strings.Contains(hints[1], "-v $PWD:/data") {
+		t.Fatalf("worng hints: %v", hints)
+	}
+}
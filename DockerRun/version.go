@@ -0,0 +1,162 @@
+package DockerRun
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//FlagVersion is the docker versions that a flag is available, a empty version means it is not limited
+type FlagVersion struct {
+	Since      string //the first version that have the flag
+	Deprecated string //the version that the flag is deprecated, it still work but a warning is emitted
+	Removed    string //the version that the flag is removed
+	Legacy     string //a flag that still work but should be replaced, it is the suggestion or its message id
+}
+
+//RunFlagVersions is the flags of docker run that depend on the docker version,
+//the key with a argument such as "mount type=image" is only checked when the argument contain it
+var RunFlagVersions = map[string]FlagVersion{
+	"gpus":             {Since: "19.03"},
+	"platform":         {Since: "20.10"},
+	"pull":             {Since: "20.10"},
+	"mount":            {Since: "17.06"},
+	"mount type=image": {Since: "28.0"},
+	"kernel-memory":    {Deprecated: "20.10"},
+	"link":             {Legacy: "version.use_network"},
+}
+
+//the version profiles that can be targeted by name
+var VersionProfiles = map[string]string{
+	"docker 19.03": "19.03",
+	"docker 20.10": "20.10",
+	"docker 23":    "23.0",
+	"docker 24":    "24.0",
+	"docker 25":    "25.0",
+	"docker 26":    "26.0",
+	"docker 27":    "27.0",
+	"docker 28":    "28.0",
+}
+
+//versionChecker check the flags by the version before they are handled by the model,
+//the flag that is not available is rejected and the warnings of deprecated flags are recorded
+type versionChecker struct {
+	optionHandler
+	version  string //the target version, all flags are available if it is empty
+	versions map[string]FlagVersion
+	warnings *[]Message
+}
+
+//check the flag and argument by the version and then handle it by the model
+func (this *versionChecker) HandleArgument(flag, arg string) error {
+	if err := this.check(flag, arg); err != nil {
+		return err
+	}
+	return this.optionHandler.HandleArgument(flag, arg)
+}
+
+//check the flag by the version and then handle it by the model
+func (this *versionChecker) HandleFlag(flag string) error {
+	if err := this.check(flag, ""); err != nil {
+		return err
+	}
+	return this.optionHandler.HandleFlag(flag)
+}
+
+//return error if the flag is not available in the version, or record the warning if it is deprecated
+func (this *versionChecker) check(flag, arg string) error {
+	for _, key := range sortedVersionKeys(this.versions) {
+		parts := strings.SplitN(key, " ", 2)
+		if parts[0] != flag || (len(parts) == 2 && !strings.Contains(arg, parts[1])) {
+			continue
+		}
+		v := this.versions[key]
+		name := "--" + key
+		if len(parts) == 2 {
+			name = fmt.Sprintf("--%s %s", parts[0], parts[1])
+		}
+		if v.Since != "" && this.version != "" && compareVersion(this.version, v.Since) < 0 {
+			return msg("version.since", name, v.Since, this.version)
+		}
+		if v.Removed != "" && this.version != "" && compareVersion(this.version, v.Removed) >= 0 {
+			return msg("version.removed", name, v.Removed)
+		}
+		warning := Message{}
+		if v.Deprecated != "" && (this.version == "" || compareVersion(this.version, v.Deprecated) >= 0) {
+			warning = msg("version.deprecated", name, v.Deprecated)
+		}
+		if v.Legacy != "" {
+			warning = msg("version.legacy", name, textMessage(v.Legacy))
+		}
+		if !warning.IsEmpty() && !containMessage(*this.warnings, warning) {
+			*this.warnings = append(*this.warnings, warning)
+		}
+	}
+	return nil
+}
+
+//create an MockContainer according to a docker run command for the docker version such as 20.10 or 'docker 26',
+//return error if it command have a worng syntax or use a flag that is not available in the version
+func NewMockContainerForVersion(dockerCmd, version string) (model MockContainer, err error) {
+	model.version, err = normalizeVersion(version)
+	if err != nil {
+		return model, err
+	}
+	model.Port = make(map[string]string)
+	model.Volume = make(map[string]string)
+	model.Env = make(map[string]string)
+	if reason := model.basicCheck(splitCommand(dockerCmd)); !reason.IsEmpty() {
+		return model, reason
+	}
+	return model, nil
+}
+
+//change a version or the name of a version profile into a version number such as 20.10
+func normalizeVersion(version string) (string, error) {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return "", nil
+	}
+	if v, have := VersionProfiles[strings.ToLower(version)]; have {
+		return v, nil
+	}
+	version = strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(version), "docker "), "v")
+	for _, part := range strings.Split(version, ".") {
+		if _, err := strconv.Atoi(part); err != nil {
+			return "", msg("version.invalid", version)
+		}
+	}
+	return version, nil
+}
+
+//compare two versions such as 20.10 and 26.0, return -1, 0 or 1
+func compareVersion(a, b string) int {
+	pa, pb := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		na, nb := 0, 0
+		if i < len(pa) {
+			na, _ = strconv.Atoi(pa[i])
+		}
+		if i < len(pb) {
+			nb, _ = strconv.Atoi(pb[i])
+		}
+		if na != nb {
+			if na < nb {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+//return the keys of the version table in order
+func sortedVersionKeys(versions map[string]FlagVersion) []string {
+	keys := make([]string, 0, len(versions))
+	for k := range versions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,43 @@
+package DockerRun
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVersionProfile(t *testing.T) {
+	expect := []struct {
+		cmd, version, err string
+	}{
+		{`docker run --pull always mysql`, "docker 20.10", ""},
+		{`docker run --pull always mysql`, "19.03", "requires docker 20.10"},
+		{`docker run --platform linux/arm64 mysql`, "docker 19.03", "requires docker 20.10"},
+		{`docker run --gpus all mysql`, "19.03.1", ""},
+		{`docker run --mount type=image,source=alpine,target=/data mysql`, "docker 26", "requires docker 28.0"},
+		{`docker run --mount type=image,source=alpine,target=/data mysql`, "28.1", ""},
+		{`docker run --mount type=tmpfs,target=/tmp mysql`, "docker 26", ""},
+		{`docker run --pull always mysql`, "v2x", "Invalid docker version"},
+	}
+	for _, e := range expect {
+		_, err := NewMockContainerForVersion(e.cmd, e.version)
+		if (e.err == "" && err != nil) || (e.err != "" && (err == nil || !strings.Contains(err.Error(), e.err))) {
+			t.Fatalf("command '%s' for %s expect error '%s' but got: %v", e.cmd, e.version, e.err, err)
+		}
+	}
+	ctr, err := NewMockContainerForVersion(`docker run --kernel-memory 50m --link db:db mysql`, "docker 26")
+	if err != nil || len(ctr.Warnings) != 2 || !strings.Contains(ctr.Warnings[0].String(), "--kernel-memory is deprecated") || !strings.Contains(ctr.Warnings[1].String(), "--link is a legacy feature") {
+		t.Fatalf("unexpect warnings: %v %v", ctr.Warnings, err)
+	}
+	ctr, _ = NewMockContainerForVersion(`docker run --kernel-memory 50m mysql`, "docker 19.03")
+	if len(ctr.Warnings) != 0 {
+		t.Fatalf("--kernel-memory is not deprecated in 19.03: %v", ctr.Warnings)
+	}
+	exercise := Exercise{Answer: `docker run --platform linux/amd64 mysql`, Version: "docker 19.03"}
+	if exercise.Judge(`docker run --platform linux/amd64 mysql`) == "" {
+		t.Fatalf("--platform should not pass in docker 19.03")
+	}
+	exercise.Version = "docker 26"
+	if res := exercise.Judge(`docker run --platform=linux/amd64 mysql`); res != "" {
+		t.Fatalf("--platform should pass in docker 26: %s", res)
+	}
+}
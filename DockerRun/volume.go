@@ -0,0 +1,376 @@
+package DockerRun
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+)
+
+//VolumeFlags is all flags allowled to used in the docker volume commands
+var VolumeFlags = map[string]FlagSet{
+	"create":  {Short: []string{"d", "o"}, Long: []string{"driver", "opt", "label", "name"}},
+	"ls":      {Short: []string{"q", "f"}, Long: []string{"quiet", "filter", "format"}, NoArg: []string{"q", "quiet"}},
+	"rm":      {Short: []string{"f"}, Long: []string{"force"}, NoArg: []string{"f", "force"}},
+	"inspect": {Short: []string{"f"}, Long: []string{"format"}},
+	"prune":   {Short: []string{"a", "f"}, Long: []string{"all", "force", "filter"}, NoArg: []string{"a", "all", "f", "force"}},
+}
+
+//the alias of the docker volume commands
+var volumeActionAlias = map[string]string{"list": "ls", "remove": "rm"}
+
+//the model to simulate the property of a docker volume command: create, ls, rm, inspect, prune
+type MockVolume struct {
+	Action  string
+	Names   []string
+	Driver  string
+	Options map[string]string
+	Label   map[string]string
+	Filters []string
+	Format  string
+	IsQuiet bool
+	IsForce bool
+	IsAll   bool
+	Client  ClientConfig //the global options before the subcommand
+}
+
+//create an MockVolume according to a docker volume command, return error if it command have a worng syntax
+func NewMockVolume(dockerCmd string) (model MockVolume, err error) {
+	model.Options = make(map[string]string)
+	model.Label = make(map[string]string)
+	cmdArray := splitCommand(dockerCmd)
+	if reason := model.basicCheck(cmdArray); !reason.IsEmpty() {
+		return model, reason
+	}
+	return model, nil
+}
+
+//check the basic syntax of a docker volume command,
+//return the fall reason or return a empty string if the command is accpeted
+//synatax: docker volume create [OPTIONS] [VOLUME], docker volume ls|prune [OPTIONS], docker volume rm|inspect VOLUME [VOLUME...]
+func (this *MockVolume) BasicCheck(cmd []string) string {
+	return this.basicCheck(cmd).String()
+}
+
+//check the basic syntax of a docker volume command, return the fall reason or a empty message if the command is accpeted
+func (this *MockVolume) basicCheck(cmd []string) Message {
+	if len(cmd) == 0 {
+		return msg("run.empty")
+	}
+	if len(cmd) < 2 {
+		return msg("run.too_short")
+	}
+	if cmd[0] != "docker" {
+		return msg("run.not_docker")
+	}
+	cmd, reason := stripGlobalOptions(cmd, &this.Client)
+	if !reason.IsEmpty() {
+		return reason
+	}
+	if len(cmd) < 3 {
+		return msg("command.too_short")
+	}
+	if cmd[1] != "volume" {
+		return msg("command.not_kind", "volume")
+	}
+	this.Action = cmd[2]
+	if alias, have := volumeActionAlias[this.Action]; have {
+		this.Action = alias
+	}
+	flagSet, have := VolumeFlags[this.Action]
+	if !have {
+		return msg("command.unknown_action", "volume", cmd[2])
+	}
+	nowAt, reason := parseOptions(cmd, 3, flagSet, this)
+	if !reason.IsEmpty() {
+		return reason
+	}
+	for _, a := range cmd[nowAt:] {
+		a = trimStr(a)
+		if !isNamedVolume(a) {
+			return msg("arg.volume_name", a)
+		}
+		if this.Action == "create" && len(this.Names) > 0 && this.Names[0] != a {
+			return msg("arg.volume_name_conflict")
+		}
+		if !findInArray(this.Names, a) {
+			this.Names = append(this.Names, a)
+		}
+	}
+	switch this.Action {
+	case "create":
+		if len(cmd[nowAt:]) > 1 {
+			return msg("command.at_most_one", "volume create")
+		}
+	case "ls", "prune":
+		if len(this.Names) != 0 {
+			return msg("command.no_arg", "volume "+this.Action)
+		}
+	case "rm", "inspect":
+		if len(this.Names) == 0 {
+			return msg("command.at_least_one", "volume "+this.Action)
+		}
+	}
+	return Message{}
+}
+
+//Setting up the property according to the flag and argument
+//if the format of arguments not right it will return error
+func (this *MockVolume) HandleArgument(flag, arg string) error {
+	arg = trimStr(arg)
+	switch flag {
+	case "d", "driver":
+		if arg == "" {
+			return msg("arg.volume_driver", arg)
+		}
+		this.Driver = arg
+	case "o", "opt", "label":
+		kv := strings.SplitN(arg, "=", 2)
+		if kv[0] == "" {
+			return msg("arg.key_value", arg)
+		}
+		if len(kv) == 1 {
+			kv = append(kv, "")
+		}
+		if flag == "label" {
+			this.Label[kv[0]] = kv[1]
+		} else {
+			this.Options[kv[0]] = kv[1]
+		}
+	case "name":
+		if !isNamedVolume(arg) {
+			return msg("arg.volume_name", arg)
+		}
+		this.Names = []string{arg}
+	case "f", "filter", "format":
+		if this.Action == "inspect" || flag == "format" {
+			this.Format = arg
+			break
+		}
+		if !strings.Contains(arg, "=") {
+			return msg("arg.filter")
+		}
+		this.Filters = append(this.Filters, arg)
+	default:
+		return msg("flag.invalid", flag)
+	}
+	return nil
+}
+
+//Setting up the property according to the flag that without argument
+//return error only if the flag is not exist
+func (this *MockVolume) HandleFlag(flag string) error {
+	switch flag {
+	case "q", "quiet":
+		this.IsQuiet = true
+	case "f", "force":
+		this.IsForce = true
+	case "a", "all":
+		this.IsAll = true
+	default:
+		return msg("flag.invalid_short", flag)
+	}
+	return nil
+}
+
+//judge if the property of a docker volume command is right by compared to the answer
+//return a string to describe the mistake or a null string if it command is accepted
+//note that here we have some config do not check: Label[]
+func JudgeVolume(test, ans *MockVolume) string {
+	return judgeVolume(test, ans).String()
+}
+
+//judge the property of a docker volume command, return the mistake or a empty message if it command is accepted
+func judgeVolume(test, ans *MockVolume) Message {
+	if test == nil {
+		return msg("judge.null_test")
+	}
+	if ans == nil {
+		return msg("judge.null_ans")
+	}
+	if test.Action != ans.Action {
+		return msg("judge.action", "volume "+ans.Action, "volume "+test.Action)
+	}
+	for _, n := range ans.Names {
+		if !findInArray(test.Names, n) {
+			return msg("judge.volume_missing", n)
+		}
+	}
+	for _, n := range test.Names {
+		if !findInArray(ans.Names, n) {
+			return msg("judge.unexpect_volume", n)
+		}
+	}
+	if normalizeVolumeDriver(test.Driver) != normalizeVolumeDriver(ans.Driver) {
+		return msg("judge.driver", normalizeVolumeDriver(ans.Driver), normalizeVolumeDriver(test.Driver))
+	}
+	for k, v := range ans.Options {
+		tv, have := test.Options[k]
+		if !have || tv != v {
+			return msg("judge.driver_opt", k, v, tv)
+		}
+	}
+	for k := range test.Options {
+		if _, have := ans.Options[k]; !have {
+			return msg("judge.unexpect_driver_opt", k)
+		}
+	}
+	for _, f := range ans.Filters {
+		if !findInArray(test.Filters, f) {
+			return msg("judge.filter", f)
+		}
+	}
+	if ans.IsQuiet && !test.IsQuiet {
+		return msg("judge.flag", "-q or --quiet")
+	}
+	if ans.IsForce && !test.IsForce {
+		return msg("judge.flag", "-f or --force")
+	}
+	if ans.IsAll && !test.IsAll {
+		return msg("judge.flag", "-a or --all")
+	}
+	if ans.Format != "" && test.Format != ans.Format {
+		return msg("judge.format", ans.Format, test.Format)
+	}
+	if reason := judgeClient(&test.Client, &ans.Client); !reason.IsEmpty() {
+		return reason
+	}
+	return Message{}
+}
+
+//apply a docker volume command to the engine, return the output of the command
+func (this *MockEngine) ManageVolume(m *MockVolume) (string, error) {
+	output := ""
+	switch m.Action {
+	case "create":
+		name := ""
+		if len(m.Names) > 0 {
+			name = m.Names[0]
+		} else { //a anonymous volume
+			this.created++
+			name = fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf("volume %d", this.created))))
+		}
+		if old, have := this.volumeConfig[name]; have && normalizeVolumeDriver(old.Driver) != normalizeVolumeDriver(m.Driver) {
+			return "", fmt.Errorf("create %s: volume name %s already in use with driver %s", name, name, normalizeVolumeDriver(old.Driver))
+		}
+		if this.volumeConfig == nil {
+			this.volumeConfig = make(map[string]*MockVolume)
+		}
+		if !this.Volumes[name] {
+			this.volumeConfig[name] = m
+		}
+		this.Volumes[name] = true
+		output = name + "\n"
+	case "ls":
+		if !m.IsQuiet {
+			output = fmt.Sprintf("%-10s%s\n", "DRIVER", "VOLUME NAME")
+		}
+		for _, name := range sortedSet(this.Volumes) {
+			if !this.matchVolumeFilters(name, m.Filters) {
+				continue
+			}
+			if m.IsQuiet {
+				output += name + "\n"
+			} else {
+				output += fmt.Sprintf("%-10s%s\n", this.volumeDriver(name), name)
+			}
+		}
+	case "rm":
+		for _, name := range m.Names {
+			if !this.Volumes[name] {
+				if m.IsForce {
+					continue
+				}
+				return output, fmt.Errorf("get %s: no such volume", name)
+			}
+			if users := this.volumeUsers(name); len(users) > 0 {
+				return output, fmt.Errorf("remove %s: volume is in use - [%s]", name, strings.Join(users, ", "))
+			}
+			this.removeVolume(name)
+			output += name + "\n"
+		}
+	case "inspect":
+		for _, name := range m.Names {
+			if !this.Volumes[name] {
+				return output, fmt.Errorf("get %s: no such volume", name)
+			}
+			output += fmt.Sprintf("%s %s\n", name, this.volumeDriver(name))
+		}
+	case "prune":
+		output = "Deleted Volumes:\n"
+		for _, name := range sortedSet(this.Volumes) {
+			if len(this.volumeUsers(name)) > 0 || !this.matchVolumeFilters(name, m.Filters) {
+				continue
+			}
+			if !m.IsAll && !this.isAnonymousVolume(name) { //only the anonymous volumes are removed without --all
+				continue
+			}
+			this.removeVolume(name)
+			output += name + "\n"
+		}
+		output += "\nTotal reclaimed space: 0B\n"
+	}
+	return output, nil
+}
+
+//return the id of the containers that mount the volume, include the stopped ones
+func (this *MockEngine) volumeUsers(name string) []string {
+	users := []string{}
+	for _, c := range this.Containers {
+		if _, have := c.Config.Volume[name]; have {
+			users = append(users, c.ID)
+		}
+	}
+	return users
+}
+
+//remove a volume and the files in it
+func (this *MockEngine) removeVolume(name string) {
+	delete(this.Volumes, name)
+	delete(this.volumeConfig, name)
+	delete(this.filesystems, name)
+}
+
+//check if a volume is created without name by docker volume create
+func (this *MockEngine) isAnonymousVolume(name string) bool {
+	m, have := this.volumeConfig[name]
+	return have && len(m.Names) == 0
+}
+
+//return the driver of a volume in engine
+func (this *MockEngine) volumeDriver(name string) string {
+	if m, have := this.volumeConfig[name]; have {
+		return normalizeVolumeDriver(m.Driver)
+	}
+	return "local"
+}
+
+//check if a volume match all the filters, only name and dangling are supported
+func (this *MockEngine) matchVolumeFilters(name string, filters []string) bool {
+	for _, f := range filters {
+		kv := strings.SplitN(f, "=", 2)
+		switch kv[0] {
+		case "name":
+			if !strings.Contains(name, kv[1]) {
+				return false
+			}
+		case "dangling":
+			dangling := len(this.volumeUsers(name)) == 0
+			if dangling != (kv[1] == "true" || kv[1] == "1") {
+				return false
+			}
+		case "driver":
+			if this.volumeDriver(name) != kv[1] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+//the default driver of volume is local
+func normalizeVolumeDriver(driver string) string {
+	if driver == "" {
+		return "local"
+	}
+	return driver
+}
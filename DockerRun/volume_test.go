@@ -0,0 +1,82 @@
+package DockerRun
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMockVolume(t *testing.T) {
+	standard := `docker volume create --driver local --opt type=tmpfs --opt device=tmpfs username_vol`
+	challenger := []string{
+		`docker volume create -o type=tmpfs -o device=tmpfs username_vol`,
+		`docker volume create --name username_vol -o device=tmpfs --opt=type=tmpfs`,
+		`sudo docker volume create --label env=dev -o type=tmpfs -o device=tmpfs "username_vol"`,
+	}
+	stdVolume, err := NewMockVolume(standard)
+	if err != nil {
+		t.Fatalf("Standard command fall: %v", err)
+	}
+	for i, cmd := range challenger {
+		v, err := NewMockVolume(cmd)
+		if err != nil {
+			t.Fatalf("Create volume fail at command %d : %v", i, err)
+		}
+		if res := JudgeVolume(&v, &stdVolume); res != "" {
+			t.Fatalf("Unpass at command %d : %v", i, res)
+		}
+	}
+	for _, cmd := range []string{
+		`docker volume create vol1 vol2`,
+		`docker volume create --name vol1 vol2`,
+		`docker volume rm`,
+		`docker volume ls vol1`,
+		`docker volume prune -x`,
+	} {
+		if _, err := NewMockVolume(cmd); err == nil {
+			t.Fatalf("worng command %s pass!", cmd)
+		}
+	}
+	v, _ := NewMockVolume(`docker volume create -o type=tmpfs username_vol`)
+	if JudgeVolume(&v, &stdVolume) == "" {
+		t.Fatalf("volume without device option should not pass")
+	}
+}
+
+func TestMockEngineVolume(t *testing.T) {
+	engine := NewMockEngine()
+	engine.RunCommands([]string{`docker run -d -v username_vol:/data --name server1 mysql`})
+	expect := [][2]string{
+		{`docker volume create cache`, ``},
+		{`docker volume create`, ``},
+		{`docker volume rm username_vol`, `volume is in use`},
+		{`docker volume rm missing`, `no such volume`},
+		{`docker volume rm -f missing`, ``},
+	}
+	for _, e := range expect {
+		v, err := NewMockVolume(e[0])
+		if err != nil {
+			t.Fatalf("Create volume fail at command %s : %v", e[0], err)
+		}
+		_, err = engine.ManageVolume(&v)
+		if (e[1] == "" && err != nil) || (e[1] != "" && (err == nil || !strings.Contains(err.Error(), e[1]))) {
+			t.Fatalf("command '%s' expect error '%s' but got: %v", e[0], e[1], err)
+		}
+	}
+	if len(engine.Volumes) != 3 {
+		t.Fatalf("expect 3 volumes but got %v", sortedSet(engine.Volumes))
+	}
+	v, _ := NewMockVolume(`docker volume prune -f`)
+	engine.ManageVolume(&v)
+	if len(engine.Volumes) != 2 || !engine.Volumes["cache"] {
+		t.Fatalf("only the anonymous volume should be pruned, got %v", sortedSet(engine.Volumes))
+	}
+	v, _ = NewMockVolume(`docker volume prune -af`)
+	engine.ManageVolume(&v)
+	if len(engine.Volumes) != 1 || !engine.Volumes["username_vol"] {
+		t.Fatalf("the volume in use should not be pruned, got %v", sortedSet(engine.Volumes))
+	}
+	v, _ = NewMockVolume(`docker volume ls -q --filter dangling=false`)
+	if output, _ := engine.ManageVolume(&v); output != "username_vol\n" {
+		t.Fatalf("unexpect output of volume ls: %q", output)
+	}
+}
@@ -0,0 +1,306 @@
+package DockerRun
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+//VolumeFS is an in-memory filesystem of a named volume, the source of a bind mount or the writable layer of a container,
+//the path in it is absolute path from the root of the volume, such as /hello.txt
+type VolumeFS struct {
+	Files map[string]string
+	Dirs  map[string]bool
+}
+
+//the container commands that can be simulated by MockEngine
+var SimulatedCommands = []string{"touch", "rm", "mkdir", "echo", "cat", "ls", "sh", "bash"}
+
+//check if a command can be simulated, a shell is only simulated with -c,
+//a shell without -c such as 'docker exec -it server1 sh' is interactive and do nothing
+func isSimulated(command string, args []string) bool {
+	if command == "sh" || command == "bash" {
+		return len(args) > 0 && args[0] == "-c"
+	}
+	return findInArray(SimulatedCommands, command)
+}
+
+//the directories that is assumed to exist in the images
+var imageDirs = []string{"/bin", "/etc", "/home", "/root", "/tmp", "/usr", "/var"}
+
+//create an empty filesystem that only have the root directory
+func NewVolumeFS() *VolumeFS {
+	return &VolumeFS{
+		Files: make(map[string]string),
+		Dirs:  map[string]bool{"/": true},
+	}
+}
+
+//return the content of a file and whether it is exist
+func (this *VolumeFS) ReadFile(name string) (string, bool) {
+	content, have := this.Files[path.Clean("/"+name)]
+	return content, have
+}
+
+//return the name of the files and directories in a directory in order
+func (this *VolumeFS) List(dir string) []string {
+	dir = path.Clean("/" + dir)
+	names := []string{}
+	for _, set := range []map[string]bool{this.Dirs, this.fileSet()} {
+		for p := range set {
+			if p != "/" && path.Dir(p) == dir {
+				names = append(names, path.Base(p))
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (this *VolumeFS) fileSet() map[string]bool {
+	set := make(map[string]bool)
+	for p := range this.Files {
+		set[p] = true
+	}
+	return set
+}
+
+//containerPath is a path of container that have been resolved to a filesystem
+type containerPath struct {
+	fs       *VolumeFS
+	path     string //path in fs
+	name     string //path in container, used in error message
+	readOnly bool   //the mount point have the option ro
+}
+
+//return the filesystem that a volume or bind source, it is created if not exist
+func (this *MockEngine) volumeFS(source string) *VolumeFS {
+	if this.filesystems == nil {
+		this.filesystems = make(map[string]*VolumeFS)
+	}
+	fs, have := this.filesystems[source]
+	if !have {
+		fs = NewVolumeFS()
+		this.filesystems[source] = fs
+	}
+	return fs
+}
+
+//return the filesystem of a named volume or bind source, return nil if nothing have been written to it
+func (this *MockEngine) VolumeFS(source string) *VolumeFS {
+	return this.filesystems[source]
+}
+
+//resolve a path used by the command of container into the filesystem of the mount point that contain it,
+//the relative path is based on workDir, which is the WorkDir of container or the one given by docker exec
+func (this *MockEngine) resolvePath(c *EngineContainer, workDir, name string) containerPath {
+	if workDir == "" {
+		workDir = "/"
+	}
+	abs := name
+	if !path.IsAbs(abs) {
+		abs = path.Join(workDir, name)
+	}
+	abs = path.Clean(abs)
+	source, target := "", ""
+	for s, t := range c.Config.Volume {
+		if (abs == t || strings.HasPrefix(abs, t+"/")) && len(t) > len(target) {
+			source, target = s, t
+		}
+	}
+	if target == "" {
+		if c.Rootfs == nil {
+			c.Rootfs = NewVolumeFS()
+			for _, dir := range append(imageDirs, c.Config.WorkDir, workDir) {
+				for d := path.Clean("/" + dir); d != "/"; d = path.Dir(d) {
+					c.Rootfs.Dirs[d] = true
+				}
+			}
+		}
+		return containerPath{c.Rootfs, abs, name, false}
+	}
+	readOnly := findInArray(strings.Split(c.Config.VolumeOption[source], ","), "ro")
+	return containerPath{this.volumeFS(source), path.Clean("/" + strings.TrimPrefix(abs, target)), name, readOnly}
+}
+
+//execute a simulated command in a container, return the output and the error printed to stderr
+func (this *MockEngine) execute(c *EngineContainer, workDir, command string, args []string) (string, error) {
+	args = append([]string{}, args...)
+	for i := range args {
+		args[i] = trimStr(args[i])
+	}
+	options, operands := []string{}, []string{}
+	for _, a := range args {
+		if strings.HasPrefix(a, "-") && len(a) > 1 && command != "echo" {
+			options = append(options, strings.TrimLeft(a, "-"))
+		} else {
+			operands = append(operands, a)
+		}
+	}
+	hasOption := func(o string) bool {
+		for _, opt := range options {
+			if strings.Contains(opt, o) {
+				return true
+			}
+		}
+		return false
+	}
+	switch command {
+	case "touch":
+		for _, name := range operands {
+			p := this.resolvePath(c, workDir, name)
+			if p.readOnly {
+				return "", fmt.Errorf("touch: cannot touch '%s': Read-only file system", name)
+			}
+			if !p.fs.Dirs[path.Dir(p.path)] {
+				return "", fmt.Errorf("touch: cannot touch '%s': No such file or directory", name)
+			}
+			if _, have := p.fs.Files[p.path]; !have && !p.fs.Dirs[p.path] {
+				p.fs.Files[p.path] = ""
+			}
+		}
+	case "rm":
+		for _, name := range operands {
+			p := this.resolvePath(c, workDir, name)
+			if p.readOnly {
+				return "", fmt.Errorf("rm: cannot remove '%s': Read-only file system", name)
+			}
+			if p.fs.Dirs[p.path] {
+				if !hasOption("r") && !hasOption("R") {
+					return "", fmt.Errorf("rm: cannot remove '%s': Is a directory", name)
+				}
+				for d := range p.fs.Dirs {
+					if d == p.path || strings.HasPrefix(d, p.path+"/") {
+						delete(p.fs.Dirs, d)
+					}
+				}
+				for f := range p.fs.Files {
+					if strings.HasPrefix(f, p.path+"/") {
+						delete(p.fs.Files, f)
+					}
+				}
+				p.fs.Dirs["/"] = true //the mount point itself can not be removed
+				continue
+			}
+			if _, have := p.fs.Files[p.path]; !have {
+				if hasOption("f") {
+					continue
+				}
+				return "", fmt.Errorf("rm: cannot remove '%s': No such file or directory", name)
+			}
+			delete(p.fs.Files, p.path)
+		}
+	case "mkdir":
+		for _, name := range operands {
+			p := this.resolvePath(c, workDir, name)
+			if p.readOnly {
+				return "", fmt.Errorf("mkdir: cannot create directory '%s': Read-only file system", name)
+			}
+			if _, have := p.fs.Files[p.path]; have || (p.fs.Dirs[p.path] && !hasOption("p")) {
+				return "", fmt.Errorf("mkdir: cannot create directory '%s': File exists", name)
+			}
+			if !p.fs.Dirs[path.Dir(p.path)] && !hasOption("p") {
+				return "", fmt.Errorf("mkdir: cannot create directory '%s': No such file or directory", name)
+			}
+			for d := p.path; d != "/"; d = path.Dir(d) {
+				p.fs.Dirs[d] = true
+			}
+		}
+	case "echo":
+		text, target, appendTo := []string{}, "", false
+		for i := 0; i < len(operands); i++ {
+			a := operands[i]
+			if strings.HasPrefix(a, ">") { //such as '> file', '>file' or '>> file'
+				appendTo = strings.HasPrefix(a, ">>")
+				target = strings.TrimLeft(a, ">")
+				if target == "" && i+1 < len(operands) {
+					i++
+					target = operands[i]
+				}
+				continue
+			}
+			text = append(text, a)
+		}
+		output := strings.Join(text, " ") + "\n"
+		if target == "" {
+			return output, nil
+		}
+		p := this.resolvePath(c, workDir, target)
+		if p.readOnly {
+			return "", fmt.Errorf("sh: can't create %s: Read-only file system", target)
+		}
+		if !p.fs.Dirs[path.Dir(p.path)] {
+			return "", fmt.Errorf("sh: can't create %s: nonexistent directory", target)
+		}
+		if p.fs.Dirs[p.path] {
+			return "", fmt.Errorf("sh: can't create %s: Is a directory", target)
+		}
+		if appendTo {
+			output = p.fs.Files[p.path] + output
+		}
+		p.fs.Files[p.path] = output
+	case "cat":
+		output := ""
+		for _, name := range operands {
+			p := this.resolvePath(c, workDir, name)
+			content, have := p.fs.Files[p.path]
+			if !have {
+				return output, fmt.Errorf("cat: can't open '%s': No such file or directory", name)
+			}
+			output += content
+		}
+		return output, nil
+	case "ls":
+		if len(operands) == 0 {
+			operands = []string{"."}
+		}
+		output := ""
+		for _, name := range operands {
+			p := this.resolvePath(c, workDir, name)
+			if _, have := p.fs.Files[p.path]; have {
+				output += name + "\n"
+				continue
+			}
+			if !p.fs.Dirs[p.path] {
+				return output, fmt.Errorf("ls: %s: No such file or directory", name)
+			}
+			for _, n := range p.fs.List(p.path) {
+				output += n + "\n"
+			}
+		}
+		return output, nil
+	case "sh", "bash": //only 'sh -c "cmd1; cmd2 && cmd3"' is supported
+		if len(args) < 2 || args[0] != "-c" {
+			return "", fmt.Errorf("%s: only -c is supported", command)
+		}
+		script := trimStr(strings.Join(args[1:], " "))
+		commands, err := splitShellList(script)
+		if err != nil {
+			return "", fmt.Errorf("%s: %v", command, err)
+		}
+		output := ""
+		var last error
+		for i, cmd := range commands {
+			if i > 0 { //'&&' run the command only if the last one succeeded and '||' only if it failed
+				if op := commands[i-1].operator; (op == "&&" && last != nil) || (op == "||" && last == nil) {
+					continue
+				}
+			}
+			words, _ := shellWords(cmd.line) //the quotes are already checked by splitShellList()
+			if len(words) == 0 {
+				continue
+			}
+			if last != nil { //the error of a command that is not the last one is printed and the script goes on
+				output += last.Error() + "\n"
+			}
+			out, err := this.execute(c, workDir, words[0], words[1:])
+			output += out
+			last = err
+		}
+		return output, last
+	default:
+		return "", fmt.Errorf("%s: command not supported", command)
+	}
+	return "", nil
+}
@@ -0,0 +1,109 @@
+package DockerRun
+
+import (
+	"testing"
+)
+
+func TestVolumeFS(t *testing.T) {
+	engine := NewMockEngine()
+	err := engine.RunCommands([]string{
+		`docker run -t --rm -w /data -v username_vol:/data username/1000010024_server:latest touch hello.txt`,
+		`docker run --rm -v username_vol:/data alpine sh -c 'mkdir -p /data/logs && echo hi > /data/logs/a.txt'`,
+		`docker run --rm -w /data/logs -v username_vol:/data alpine sh -c 'echo again >> a.txt'`,
+		`docker run -d -w /tmp --name temp alpine touch hello.txt`,
+	})
+	if err != nil {
+		t.Fatalf("run commands fail: %v", err)
+	}
+	vol := engine.VolumeFS("username_vol")
+	if vol == nil {
+		t.Fatalf("volume filesystem should be created")
+	}
+	if content, have := vol.ReadFile("/logs/a.txt"); !have || content != "hi\nagain\n" {
+		t.Fatalf("unexpect content of a.txt: %q", content)
+	}
+	if names := vol.List("/"); len(names) != 2 || names[0] != "hello.txt" || names[1] != "logs" {
+		t.Fatalf("unexpect files in volume: %v", names)
+	}
+	temp := engine.Container("temp")
+	if temp.State != StateExited || temp.Rootfs == nil {
+		t.Fatalf("container should exit after the command")
+	}
+	if _, have := temp.Rootfs.ReadFile("/tmp/hello.txt"); !have {
+		t.Fatalf("file outside volume should be written to the container")
+	}
+	if err = engine.RunCommands([]string{`docker run -d -w /data --name server3 -v username_vol:/data username/1000010024_server:latest rm hello.txt`}); err != nil {
+		t.Fatalf("run commands fail: %v", err)
+	}
+	if _, have := vol.ReadFile("/hello.txt"); have {
+		t.Fatalf("hello.txt should be removed")
+	}
+	if err = engine.RunCommands([]string{`docker run --name server4 -v username_vol:/data alpine cat /data/hello.txt`}); err != nil {
+		t.Fatalf("run commands fail: %v", err)
+	}
+	if server4 := engine.Container("server4"); server4.ExitCode != 1 || server4.Logs != "cat: can't open '/data/hello.txt': No such file or directory\n" {
+		t.Fatalf("unexpect result of cat: %d %q", server4.ExitCode, server4.Logs)
+	}
+}
+
+func TestVolumeFSReadOnlyAndRemove(t *testing.T) {
+	engine := NewMockEngine()
+	err := engine.RunCommands([]string{
+		`docker run -d --rm --name temp -v username_vol:/data alpine touch /data/a.txt`,
+		`docker run --name ro1 -v username_vol:/data:ro alpine touch /data/b.txt`,
+		`docker run --name ro2 -v username_vol:/data:ro alpine sh -c 'echo hi > /data/c.txt'`,
+		`docker run --name ro3 -v username_vol:/data:ro alpine touch /tmp/d.txt`,
+	})
+	if err != nil {
+		t.Fatalf("run commands fail: %v", err)
+	}
+	if engine.Container("temp") != nil {
+		t.Fatalf("detached container with --rm should be removed after it exit")
+	}
+	expect := map[string]string{
+		"ro1": "touch: cannot touch '/data/b.txt': Read-only file system\n",
+		"ro2": "sh: can't create /data/c.txt: Read-only file system\n",
+		"ro3": "",
+	}
+	for name, logs := range expect {
+		if c := engine.Container(name); c.Logs != logs || (logs != "") != (c.ExitCode == 1) {
+			t.Fatalf("unexpect result of %s: %d %q", name, c.ExitCode, c.Logs)
+		}
+	}
+	if names := engine.VolumeFS("username_vol").List("/"); len(names) != 1 || names[0] != "a.txt" {
+		t.Fatalf("read only volume should not be written: %v", names)
+	}
+	if err = engine.RunCommands([]string{`docker run -d --rm --name web nginx`, `docker stop web`}); err != nil || engine.Container("web") != nil {
+		t.Fatalf("container with --rm should be removed after docker stop: %v", err)
+	}
+}
+
+func TestVolumeFSShellList(t *testing.T) {
+	engine := NewMockEngine()
+	err := engine.RunCommands([]string{
+		`docker run --name quote -v username_vol:/data alpine sh -c "echo 'a;b && c' > /data/q.txt; cat /data/q.txt"`,
+		`docker run --name next -v username_vol:/data alpine sh -c 'cat /data/none; echo next'`,
+		`docker run --name and -v username_vol:/data alpine sh -c 'cat /data/none && echo skipped'`,
+		`docker run --name or -v username_vol:/data alpine sh -c 'cat /data/none || echo fallback'`,
+		`docker run --name ok -v username_vol:/data alpine sh -c 'echo ok || echo skipped && echo done'`,
+	})
+	if err != nil {
+		t.Fatalf("run commands fail: %v", err)
+	}
+	notFound := "cat: can't open '/data/none': No such file or directory\n"
+	expect := map[string]struct {
+		logs     string
+		exitCode int
+	}{
+		"quote": {"a;b && c\n", 0},
+		"next":  {notFound + "next\n", 0},
+		"and":   {notFound, 1},
+		"or":    {notFound + "fallback\n", 0},
+		"ok":    {"ok\ndone\n", 0},
+	}
+	for name, e := range expect {
+		if c := engine.Container(name); c.Logs != e.logs || c.ExitCode != e.exitCode {
+			t.Fatalf("unexpect result of %s: %d %q", name, c.ExitCode, c.Logs)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package DockerRun
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+//a tiny yaml reader and writer that only support the block style mapping and list that used by docker-compose file,
+//the value read by parseYAML() is one of string, []interface{}, map[string]interface{}
+
+//yamlLine is a line of yaml text without comment
+type yamlLine struct {
+	num    int
+	indent int
+	text   string
+}
+
+//write a 'key: value' pair in yaml format, value is one of string, int, bool, []string, map[string]string
+func writeYAMLValue(sb *strings.Builder, key string, value interface{}, indent int) {
+	prefix := strings.Repeat("  ", indent)
+	switch v := value.(type) {
+	case []string:
+		sb.WriteString(fmt.Sprintf("%s%s:\n", prefix, key))
+		for _, item := range v {
+			sb.WriteString(fmt.Sprintf("%s  - %s\n", prefix, yamlScalar(item)))
+		}
+	case map[string]string:
+		sb.WriteString(fmt.Sprintf("%s%s:\n", prefix, key))
+		for _, k := range sortedKeys(v) {
+			sb.WriteString(fmt.Sprintf("%s  %s: %s\n", prefix, yamlScalar(k), yamlScalar(v[k])))
+		}
+	case string:
+		sb.WriteString(fmt.Sprintf("%s%s: %s\n", prefix, key, yamlScalar(v)))
+	default:
+		sb.WriteString(fmt.Sprintf("%s%s: %v\n", prefix, key, v))
+	}
+}
+
+//quote a string if it can not be used as a plain yaml scalar
+func yamlScalar(str string) string {
+	plainReg := regexp.MustCompile(`^[\w./$@~-][\w ./$@~=+-]*$`)
+	if plainReg.MatchString(str) && !strings.HasSuffix(str, " ") && !isYAMLKeyword(str) {
+		return str
+	}
+	data, _ := json.Marshal(str)
+	return string(data)
+}
+
+//check if a plain string will be read as other type by yaml, such as true, null or 8080
+func isYAMLKeyword(str string) bool {
+	switch strings.ToLower(str) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~", "":
+		return true
+	}
+	numReg := regexp.MustCompile(`^[-+]?[\d._]+([eE][-+]?\d+)?$`)
+	return numReg.MatchString(str)
+}
+
+//parse a yaml document into nested map, list and string
+func parseYAML(text string) (interface{}, error) {
+	lines := []yamlLine{}
+	for i, raw := range strings.Split(strings.Replace(text, "\t", "  ", -1), "\n") {
+		line := strings.TrimRight(stripYAMLComment(raw), " \r")
+		trimed := strings.TrimLeft(line, " ")
+		if trimed == "" || trimed == "---" {
+			continue
+		}
+		lines = append(lines, yamlLine{i + 1, len(line) - len(trimed), trimed})
+	}
+	if len(lines) == 0 {
+		return map[string]interface{}{}, nil
+	}
+	value, next, err := parseYAMLBlock(lines, 0, lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if next < len(lines) {
+		return nil, fmt.Errorf("yaml: unexpect content at line %d: %s", lines[next].num, lines[next].text)
+	}
+	return value, nil
+}
+
+//parse the lines start from lines[at] that have the same indent, return the value and the index of next unread line
+func parseYAMLBlock(lines []yamlLine, at, indent int) (interface{}, int, error) {
+	if strings.HasPrefix(lines[at].text, "-") {
+		list := []interface{}{}
+		for at < len(lines) && lines[at].indent == indent && isYAMLListItem(lines[at].text) {
+			rest := strings.TrimLeft(lines[at].text[1:], " ")
+			if rest == "" { //the item is a block in the following lines
+				if at+1 >= len(lines) || lines[at+1].indent <= indent {
+					list = append(list, "")
+					at++
+					continue
+				}
+				value, next, err := parseYAMLBlock(lines, at+1, lines[at+1].indent)
+				if err != nil {
+					return nil, 0, err
+				}
+				list = append(list, value)
+				at = next
+				continue
+			}
+			if _, _, isPair := splitYAMLPair(rest); isPair { //such as '- name: x', the item is a mapping
+				itemIndent := indent + len(lines[at].text) - len(rest)
+				lines[at] = yamlLine{lines[at].num, itemIndent, rest}
+				value, next, err := parseYAMLBlock(lines, at, itemIndent)
+				if err != nil {
+					return nil, 0, err
+				}
+				list = append(list, value)
+				at = next
+				continue
+			}
+			value, err := parseYAMLScalar(rest)
+			if err != nil {
+				return nil, 0, fmt.Errorf("yaml: line %d: %v", lines[at].num, err)
+			}
+			list = append(list, value)
+			at++
+		}
+		return list, at, nil
+	}
+	mapping := map[string]interface{}{}
+	for at < len(lines) && lines[at].indent == indent {
+		key, rest, isPair := splitYAMLPair(lines[at].text)
+		if !isPair {
+			return nil, 0, fmt.Errorf("yaml: line %d: expect 'key: value' but got %s", lines[at].num, lines[at].text)
+		}
+		if _, have := mapping[key]; have {
+			return nil, 0, fmt.Errorf("yaml: line %d: duplicate key %s", lines[at].num, key)
+		}
+		at++
+		if rest != "" {
+			value, err := parseYAMLScalar(rest)
+			if err != nil {
+				return nil, 0, fmt.Errorf("yaml: line %d: %v", lines[at-1].num, err)
+			}
+			mapping[key] = value
+			continue
+		}
+		//the value is a block in the following lines, note that a list can have the same indent as its key
+		if at < len(lines) && (lines[at].indent > indent || (lines[at].indent == indent && isYAMLListItem(lines[at].text))) {
+			value, next, err := parseYAMLBlock(lines, at, lines[at].indent)
+			if err != nil {
+				return nil, 0, err
+			}
+			mapping[key] = value
+			at = next
+		} else {
+			mapping[key] = ""
+		}
+	}
+	if at < len(lines) && lines[at].indent > indent {
+		return nil, 0, fmt.Errorf("yaml: line %d: bad indentation", lines[at].num)
+	}
+	return mapping, at, nil
+}
+
+//parse a single value, such as "8080:80", 'hello', [a, b] or {}
+func parseYAMLScalar(text string) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(text, "\""):
+		var str string
+		if err := json.Unmarshal([]byte(text), &str); err != nil {
+			return nil, fmt.Errorf("invalid double quoted string %s", text)
+		}
+		return str, nil
+	case strings.HasPrefix(text, "'"):
+		if len(text) < 2 || !strings.HasSuffix(text, "'") {
+			return nil, fmt.Errorf("invalid single quoted string %s", text)
+		}
+		return strings.Replace(text[1:len(text)-1], "''", "'", -1), nil
+	case strings.HasPrefix(text, "["):
+		if !strings.HasSuffix(text, "]") {
+			return nil, fmt.Errorf("invalid flow sequence %s", text)
+		}
+		list := []interface{}{}
+		for _, item := range splitYAMLFlow(text[1 : len(text)-1]) {
+			value, err := parseYAMLScalar(item)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		return list, nil
+	case strings.HasPrefix(text, "{"):
+		if strings.TrimSpace(text[1:len(text)-1]) != "" || !strings.HasSuffix(text, "}") {
+			return nil, fmt.Errorf("flow mapping is not supported: %s", text)
+		}
+		return map[string]interface{}{}, nil
+	}
+	return text, nil
+}
+
+//split 'key: value' or 'key:', the key can be quoted
+func splitYAMLPair(text string) (key, value string, ok bool) {
+	reg := regexp.MustCompile(`^("[^"]*"|'[^']*'|[^\s"'\[{#-][^:]*|-[^\s:][^:]*):( +(.*))?$`)
+	match := reg.FindStringSubmatch(text)
+	if match == nil {
+		return "", "", false
+	}
+	return trimStr(strings.TrimSpace(match[1])), strings.TrimSpace(match[3]), true
+}
+
+//split the content of a flow sequence by comma that not in quotes
+func splitYAMLFlow(text string) []string {
+	items := []string{}
+	quote, start := byte(0), 0
+	for i := 0; i <= len(text); i++ {
+		if i == len(text) || (quote == 0 && text[i] == ',') {
+			if item := strings.TrimSpace(text[start:i]); item != "" {
+				items = append(items, item)
+			}
+			start = i + 1
+		} else if quote == 0 && (text[i] == '"' || text[i] == '\'') {
+			quote = text[i]
+		} else if quote != 0 && text[i] == quote {
+			quote = 0
+		}
+	}
+	return items
+}
+
+//judge if a line is an element of block list, such as '- hello' or '-'
+func isYAMLListItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+//remove the comment start with '#' that not in quotes
+func stripYAMLComment(line string) string {
+	quote := byte(0)
+	for i := 0; i < len(line); i++ {
+		switch {
+		case quote == 0 && (line[i] == '"' || line[i] == '\''):
+			quote = line[i]
+		case quote != 0 && line[i] == quote:
+			quote = 0
+		case quote == 0 && line[i] == '#' && (i == 0 || line[i-1] == ' '):
+			return line[:i]
+		}
+	}
+	return line
+}
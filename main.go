@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"strings"
 
 	dk "./DockerRun"
 )
@@ -18,12 +19,31 @@ func main() {
 		reader := bufio.NewReader(os.Stdin)
 		fmt.Print("Docker command: # ")
 		cmd, _ := reader.ReadString('\n')
+		if strings.HasPrefix(cmd, "explain ") { //such as: explain docker run -d nginx
+			parts, err := dk.Explain(strings.TrimPrefix(cmd, "explain "))
+			if err != nil {
+				fmt.Println("Error: ", err)
+			} else {
+				fmt.Print(parts.Text())
+			}
+			continue
+		}
 		ctr, err := dk.NewMockContainer(cmd)
 		if err != nil {
 			fmt.Println("Error: ", err)
+			suggestion := dk.Suggest(cmd)
+			for _, hint := range suggestion.Hints {
+				fmt.Println("Hint: ", hint)
+			}
+			if suggestion.Corrected != "" {
+				fmt.Println("Did you mean: ", suggestion.Corrected)
+			}
 		} else {
 			res := dk.Judge(&ctr, &ans)
 			fmt.Println(res)
+			if res != "" {
+				fmt.Print(dk.RenderDiff(dk.Diff(&ctr, &ans), true))
+			}
 		}
 	}
 }